@@ -2,6 +2,8 @@ package jira
 
 import (
 	"bytes"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -221,6 +223,28 @@ func TestAuthenticationService_GetUserInfo_Success(t *testing.T) {
 	}
 }
 
+func TestAuthenticationService_GetUserInfo_InvalidBody_Fail(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/auth/1/session", func(w http.ResponseWriter, r *http.Request) {
+		if r.Method == "POST" {
+			fmt.Fprint(w, `{"session":{"name":"JSESSIONID","value":"12345678901234567890"}}`)
+		}
+		if r.Method == "GET" {
+			fmt.Fprint(w, `{"name":`)
+		}
+	})
+
+	testClient.Authentication.AcquireSessionCookie("foo", "bar")
+
+	_, err := testClient.Authentication.GetCurrentUser()
+	var syntaxErr *json.SyntaxError
+	if !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected the JSON error to be wrapped, recieved %v", err)
+	}
+}
+
 func TestAuthenticationService_Logout_Success(t *testing.T) {
 	setup()
 	defer teardown()
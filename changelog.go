@@ -0,0 +1,212 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+)
+
+// Changelog represents the history of an issue.
+// It is part of an Issue if the issue was requested with the expand option "changelog".
+type Changelog struct {
+	StartAt    int                `json:"startAt" structs:"startAt"`
+	MaxResults int                `json:"maxResults" structs:"maxResults"`
+	Total      int                `json:"total" structs:"total"`
+	Histories  []ChangelogHistory `json:"histories,omitempty" structs:"histories,omitempty"`
+}
+
+// ChangelogHistory represents one change of an issue.
+// A single change can modify several fields at once, e.g. a transition that sets the status and the resolution.
+type ChangelogHistory struct {
+	ID      string          `json:"id" structs:"id"`
+	Author  User            `json:"author" structs:"author"`
+	Created *Time           `json:"created,omitempty" structs:"created,omitempty,omitnested"`
+	Items   []ChangelogItem `json:"items" structs:"items"`
+}
+
+// ChangelogItem represents the modification of a single field within a ChangelogHistory.
+// From and To contain the raw values (e.g. ids), FromString and ToString the display values.
+type ChangelogItem struct {
+	Field      string `json:"field" structs:"field"`
+	FieldType  string `json:"fieldtype" structs:"fieldtype"`
+	FieldID    string `json:"fieldId,omitempty" structs:"fieldId,omitempty"`
+	From       string `json:"from" structs:"from"`
+	FromString string `json:"fromString" structs:"fromString"`
+	To         string `json:"to" structs:"to"`
+	ToString   string `json:"toString" structs:"toString"`
+}
+
+// StatusTransition represents a change of the status of an issue.
+type StatusTransition struct {
+	FromID    string
+	From      string
+	ToID      string
+	To        string
+	Author    User
+	Time      time.Time
+	HistoryID string
+}
+
+// changelogPage is the response of the paginated changelog endpoint
+type changelogPage struct {
+	StartAt    int                `json:"startAt" structs:"startAt"`
+	MaxResults int                `json:"maxResults" structs:"maxResults"`
+	Total      int                `json:"total" structs:"total"`
+	IsLast     bool               `json:"isLast" structs:"isLast"`
+	Values     []ChangelogHistory `json:"values" structs:"values"`
+}
+
+// ChangelogOptions specifies the optional parameters to GetChangelog
+type ChangelogOptions struct {
+	// StartAt: The starting index of the returned histories. Base index: 0.
+	StartAt int `url:"startAt,omitempty"`
+	// MaxResults: The maximum number of histories to return per page. Default: 100.
+	MaxResults int `url:"maxResults,omitempty"`
+}
+
+// CreatedTime returns the time of the change.
+// It returns an error if the history has no created timestamp.
+func (h *ChangelogHistory) CreatedTime() (time.Time, error) {
+	if h.Created == nil {
+		return time.Time{}, fmt.Errorf("no created timestamp")
+	}
+	return time.Time(*h.Created), nil
+}
+
+// GetChangelogWithContext returns one page of the changelog of the issue issueID.
+// The paging information of the page is part of the returned Response.
+//
+// JIRA API docs: https://developer.atlassian.com/cloud/jira/platform/rest/v2/#api-rest-api-2-issue-issueIdOrKey-changelog-get
+func (s *IssueService) GetChangelogWithContext(ctx context.Context, issueID string, options *ChangelogOptions) ([]ChangelogHistory, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/changelog", issueID)
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	page := new(changelogPage)
+	resp, err := s.client.Do(req, page)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return page.Values, resp, nil
+}
+
+// GetChangelog wraps GetChangelogWithContext using the background context.
+func (s *IssueService) GetChangelog(issueID string, options *ChangelogOptions) ([]ChangelogHistory, *Response, error) {
+	return s.GetChangelogWithContext(context.Background(), issueID, options)
+}
+
+// GetAllChangelogWithContext returns the complete changelog of the issue issueID.
+// All pages are fetched one after the other.
+func (s *IssueService) GetAllChangelogWithContext(ctx context.Context, issueID string) (*Changelog, *Response, error) {
+	changelog := &Changelog{}
+	options := &ChangelogOptions{}
+	for {
+		histories, resp, err := s.GetChangelogWithContext(ctx, issueID, options)
+		if err != nil {
+			return nil, resp, err
+		}
+		changelog.Histories = append(changelog.Histories, histories...)
+		options.StartAt += len(histories)
+		if resp.IsLast || len(histories) == 0 || options.StartAt >= resp.Total {
+			changelog.MaxResults = len(changelog.Histories)
+			changelog.Total = len(changelog.Histories)
+			return changelog, resp, nil
+		}
+	}
+}
+
+// GetAllChangelog wraps GetAllChangelogWithContext using the background context.
+func (s *IssueService) GetAllChangelog(issueID string) (*Changelog, *Response, error) {
+	return s.GetAllChangelogWithContext(context.Background(), issueID)
+}
+
+// timedHistory is a ChangelogHistory with its parsed creation time
+type timedHistory struct {
+	*ChangelogHistory
+	created time.Time
+}
+
+// sortedHistories returns the histories of c in chronological order.
+func (c *Changelog) sortedHistories() ([]timedHistory, error) {
+	histories := make([]timedHistory, 0, len(c.Histories))
+	for i := range c.Histories {
+		created, err := c.Histories[i].CreatedTime()
+		if err != nil {
+			return nil, fmt.Errorf("history %s: %w", c.Histories[i].ID, err)
+		}
+		histories = append(histories, timedHistory{ChangelogHistory: &c.Histories[i], created: created})
+	}
+	sort.SliceStable(histories, func(i, j int) bool {
+		return histories[i].created.Before(histories[j].created)
+	})
+	return histories, nil
+}
+
+// isField reports if the item modified field. field is either the name or the id of the field.
+func (item *ChangelogItem) isField(field string) bool {
+	return strings.EqualFold(item.Field, field) || (item.FieldID != "" && strings.EqualFold(item.FieldID, field))
+}
+
+// StatusTransitions returns all status changes of the changelog in chronological order.
+func (c *Changelog) StatusTransitions() ([]StatusTransition, error) {
+	histories, err := c.sortedHistories()
+	if err != nil {
+		return nil, err
+	}
+
+	var transitions []StatusTransition
+	for _, h := range histories {
+		for _, item := range h.Items {
+			if !item.isField("status") {
+				continue
+			}
+			transitions = append(transitions, StatusTransition{
+				FromID:    item.From,
+				From:      item.FromString,
+				ToID:      item.To,
+				To:        item.ToString,
+				Author:    h.Author,
+				Time:      h.created,
+				HistoryID: h.ID,
+			})
+		}
+	}
+	return transitions, nil
+}
+
+// FieldValueAt returns the display value the field had at the time t.
+// field is either the name (e.g. "status") or the id (e.g. "customfield_10002") of the field.
+// As the changelog only records changes, current is returned if the field was never changed.
+func (c *Changelog) FieldValueAt(field string, t time.Time, current string) (string, error) {
+	histories, err := c.sortedHistories()
+	if err != nil {
+		return "", err
+	}
+
+	value, found := current, false
+	for _, h := range histories {
+		for _, item := range h.Items {
+			if !item.isField(field) {
+				continue
+			}
+			if h.created.After(t) {
+				if !found {
+					// the first change after t tells the value before
+					return item.FromString, nil
+				}
+				return value, nil
+			}
+			value, found = item.ToString, true
+		}
+	}
+	return value, nil
+}
@@ -0,0 +1,142 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testChangelogHistories = `[
+	{"id":"10001","author":{"name":"fred"},"created":"2016-03-17T10:00:00.000+0000","items":[{"field":"status","fieldtype":"jira","fieldId":"status","from":"1","fromString":"Open","to":"3","toString":"In Progress"}]},
+	{"id":"10002","author":{"name":"fred"},"created":"2016-03-18T10:00:00.000+0000","items":[{"field":"Story Points","fieldtype":"custom","fieldId":"customfield_10002","from":null,"fromString":null,"to":"3","toString":"3"}]},
+	{"id":"10003","author":{"name":"alice"},"created":"2016-03-20T10:00:00.000+0000","items":[{"field":"status","fieldtype":"jira","from":"3","fromString":"In Progress","to":"10001","toString":"Done"},{"field":"resolution","fieldtype":"jira","from":null,"fromString":null,"to":"1","toString":"Fixed"}]}
+]`
+
+func testChangelog(t *testing.T) *Changelog {
+	issue := new(Issue)
+	if err := json.Unmarshal([]byte(`{"key":"EX-1","changelog":{"startAt":0,"maxResults":3,"total":3,"histories":`+testChangelogHistories+`}}`), issue); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return issue.Changelog
+}
+
+func TestIssueService_Get_ExpandChangelog(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/issue/EX-1?expand=changelog")
+
+		fmt.Fprint(w, `{"key":"EX-1","changelog":{"startAt":0,"maxResults":3,"total":3,"histories":`+testChangelogHistories+`}}`)
+	})
+
+	issue, _, err := testClient.Issue.GetWithOptions("EX-1", &GetQueryOptions{Expand: "changelog"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if issue.Changelog == nil {
+		t.Fatal("Expected changelog. Changelog is nil")
+	}
+	if len(issue.Changelog.Histories) != 3 {
+		t.Errorf("Expected 3 histories. Got %d", len(issue.Changelog.Histories))
+	}
+	if item := issue.Changelog.Histories[2].Items[1]; item.Field != "resolution" || item.ToString != "Fixed" {
+		t.Errorf("Unexpected item %+v", item)
+	}
+}
+
+func TestIssueService_GetAllChangelog(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1/changelog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"startAt":0,"maxResults":2,"total":3,"isLast":false,"values":[{"id":"10001","created":"2016-03-17T10:00:00.000+0000"},{"id":"10002","created":"2016-03-18T10:00:00.000+0000"}]}`)
+		case "2":
+			fmt.Fprint(w, `{"startAt":2,"maxResults":2,"total":3,"isLast":true,"values":[{"id":"10003","created":"2016-03-20T10:00:00.000+0000"}]}`)
+		default:
+			t.Errorf("Unexpected startAt %s", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	changelog, resp, err := testClient.Issue.GetAllChangelog("EX-1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(changelog.Histories) != 3 || changelog.Histories[2].ID != "10003" {
+		t.Errorf("Unexpected histories %+v", changelog.Histories)
+	}
+	if !resp.IsLast || resp.StartAt != 2 {
+		t.Errorf("Expected paging info of the last page. Got %+v", resp)
+	}
+}
+
+func TestChangelog_StatusTransitions(t *testing.T) {
+	transitions, err := testChangelog(t).StatusTransitions()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(transitions) != 2 {
+		t.Fatalf("Expected 2 transitions. Got %d", len(transitions))
+	}
+
+	want := StatusTransition{
+		FromID:    "3",
+		From:      "In Progress",
+		ToID:      "10001",
+		To:        "Done",
+		Author:    User{Name: "alice"},
+		Time:      time.Date(2016, 3, 20, 10, 0, 0, 0, time.UTC),
+		HistoryID: "10003",
+	}
+	got := transitions[1]
+	if !got.Time.Equal(want.Time) {
+		t.Errorf("Expected time %s. Got %s", want.Time, got.Time)
+	}
+	got.Time = want.Time
+	if got != want {
+		t.Errorf("Expected %+v. Got %+v", want, got)
+	}
+}
+
+func TestChangelog_FieldValueAt(t *testing.T) {
+	c := testChangelog(t)
+	tests := []struct {
+		field   string
+		at      time.Time
+		current string
+		want    string
+	}{
+		{"status", time.Date(2016, 3, 16, 0, 0, 0, 0, time.UTC), "Done", "Open"},
+		{"status", time.Date(2016, 3, 19, 0, 0, 0, 0, time.UTC), "Done", "In Progress"},
+		{"Status", time.Date(2016, 3, 21, 0, 0, 0, 0, time.UTC), "Done", "Done"},
+		{"customfield_10002", time.Date(2016, 3, 17, 0, 0, 0, 0, time.UTC), "3", ""},
+		{"Story Points", time.Date(2016, 3, 19, 0, 0, 0, 0, time.UTC), "3", "3"},
+		{"priority", time.Date(2016, 3, 19, 0, 0, 0, 0, time.UTC), "High", "High"},
+	}
+
+	for _, test := range tests {
+		got, err := c.FieldValueAt(test.field, test.at, test.current)
+		if err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+		if got != test.want {
+			t.Errorf("FieldValueAt(%q, %s): Expected %q. Got %q", test.field, test.at, test.want, got)
+		}
+	}
+}
+
+func TestChangelog_InvalidCreated(t *testing.T) {
+	var history ChangelogHistory
+	if err := json.Unmarshal([]byte(`{"id":"1","created":"yesterday"}`), &history); err == nil {
+		t.Error("Expected an error for an invalid timestamp")
+	}
+
+	c := &Changelog{Histories: []ChangelogHistory{{ID: "1"}}}
+	if _, err := c.StatusTransitions(); err == nil {
+		t.Error("Expected an error for a missing timestamp")
+	}
+}
@@ -0,0 +1,181 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+)
+
+// defaultConflictAttempts is the number of update attempts of UpdateIfUnchanged if a Merge function is given
+const defaultConflictAttempts = 3
+
+// ConflictError is returned by UpdateIfUnchanged if the issue was modified since the snapshot was taken.
+type ConflictError struct {
+	IssueID string
+	// Updated timestamps of the snapshot and the current issue
+	SnapshotUpdated time.Time
+	CurrentUpdated  time.Time
+	// Fields lists the checked fields that differ, if UpdateIfUnchangedOptions.Fields was given.
+	Fields []string
+	// Current is the issue as it was read before the update.
+	// Only its updated timestamp and the checked fields are loaded.
+	Current *Issue
+}
+
+func (e *ConflictError) Error() string {
+	if len(e.Fields) > 0 {
+		return fmt.Sprintf("issue %s was modified since %s: fields %s changed", e.IssueID, Time(e.SnapshotUpdated), strings.Join(e.Fields, ", "))
+	}
+	return fmt.Sprintf("issue %s was modified since %s (updated %s)", e.IssueID, Time(e.SnapshotUpdated), Time(e.CurrentUpdated))
+}
+
+// IsConflict reports if err is a *ConflictError.
+func IsConflict(err error) bool {
+	var conflictErr *ConflictError
+	return errors.As(err, &conflictErr)
+}
+
+// UpdateIfUnchangedOptions specifies the optional parameters to UpdateIfUnchanged
+type UpdateIfUnchangedOptions struct {
+	// Fields limits the check to the given fields (ids like "summary" or "customfield_10002").
+	// The update is performed if the issue was modified since the snapshot, but none of these fields changed.
+	// By default, any modification of the issue is a conflict.
+	Fields []string
+	// Merge is called on a conflict with the current issue and the request that could not be sent.
+	// Only the updated timestamp and Fields of the current issue are loaded, see ConflictError.Current.
+	// It returns the request to retry with, based on current. If Merge returns an error, the update is aborted with it.
+	// By default, the ConflictError is returned.
+	Merge func(current *Issue, updateReq *UpdateIssueRequest) (*UpdateIssueRequest, error)
+	// MaxAttempts is the maximum number of update attempts if Merge is given. Default: 3.
+	MaxAttempts int
+	// UpdateQueryOptions are passed on to the update request.
+	UpdateQueryOptions *UpdateQueryOptions
+}
+
+// UpdateIfUnchangedWithContext updates the issue of snapshot with updateReq, if the issue was not modified since snapshot was read.
+// The current issue is read right before the update. If its "updated" timestamp differs from the one of snapshot,
+// a *ConflictError is returned and nothing is updated, unless options allow to proceed or to merge.
+// options can be nil.
+//
+// JIRA does not support conditional updates of issues, so changes that happen
+// between the check and the update can still be overwritten. The window is kept as small as possible.
+func (s *IssueService) UpdateIfUnchangedWithContext(ctx context.Context, snapshot *Issue, updateReq *UpdateIssueRequest, options *UpdateIfUnchangedOptions) (*Response, error) {
+	opt := UpdateIfUnchangedOptions{}
+	if options != nil {
+		opt = *options
+	}
+	if opt.MaxAttempts <= 0 {
+		opt.MaxAttempts = defaultConflictAttempts
+	}
+
+	issueID := snapshot.Key
+	if issueID == "" {
+		issueID = snapshot.ID
+	}
+	if issueID == "" {
+		return nil, fmt.Errorf("issue has neither a key nor an id")
+	}
+	if snapshot.Fields == nil || snapshot.Fields.Updated == nil || time.Time(*snapshot.Fields.Updated).IsZero() {
+		return nil, fmt.Errorf("snapshot of issue %s has no updated timestamp", issueID)
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := s.checkUnchanged(ctx, issueID, snapshot, opt.Fields)
+		if err != nil {
+			var conflictErr *ConflictError
+			if opt.Merge == nil || attempt >= opt.MaxAttempts || !errors.As(err, &conflictErr) {
+				return resp, err
+			}
+			if updateReq, err = opt.Merge(conflictErr.Current, updateReq); err != nil {
+				return resp, err
+			}
+			snapshot = conflictErr.Current
+			continue
+		}
+
+		return s.UpdateIssueWithOptionsWithContext(ctx, issueID, updateReq, opt.UpdateQueryOptions)
+	}
+}
+
+// UpdateIfUnchanged wraps UpdateIfUnchangedWithContext using the background context.
+func (s *IssueService) UpdateIfUnchanged(snapshot *Issue, updateReq *UpdateIssueRequest, options *UpdateIfUnchangedOptions) (*Response, error) {
+	return s.UpdateIfUnchangedWithContext(context.Background(), snapshot, updateReq, options)
+}
+
+// checkUnchanged reads the updated timestamp and fields of the current issue and compares them to snapshot.
+// It returns a *ConflictError if the issue was modified.
+func (s *IssueService) checkUnchanged(ctx context.Context, issueID string, snapshot *Issue, fields []string) (*Response, error) {
+	// Only the compared fields are read, to keep the request and the window before the update small
+	options := &GetQueryOptions{Fields: append([]string{"updated"}, fields...)}
+	current, resp, err := s.GetWithOptionsWithContext(ctx, issueID, options)
+	if err != nil {
+		return resp, err
+	}
+
+	var currentUpdated time.Time
+	if current.Fields != nil && current.Fields.Updated != nil {
+		currentUpdated = time.Time(*current.Fields.Updated)
+	}
+	snapshotUpdated := time.Time(*snapshot.Fields.Updated)
+	if currentUpdated.Equal(snapshotUpdated) {
+		return resp, nil
+	}
+
+	conflictErr := &ConflictError{
+		IssueID:         issueID,
+		SnapshotUpdated: snapshotUpdated,
+		CurrentUpdated:  currentUpdated,
+		Current:         current,
+	}
+	if len(fields) == 0 {
+		return resp, conflictErr
+	}
+
+	changed, err := changedFields(snapshot.Fields, current.Fields, fields)
+	if err != nil {
+		return resp, err
+	}
+	if len(changed) == 0 {
+		return resp, nil
+	}
+	conflictErr.Fields = changed
+	return resp, conflictErr
+}
+
+// changedFields returns the fields whose JSON representation differs between a and b.
+func changedFields(a, b *IssueFields, fields []string) ([]string, error) {
+	aValues, err := fieldValues(a)
+	if err != nil {
+		return nil, err
+	}
+	bValues, err := fieldValues(b)
+	if err != nil {
+		return nil, err
+	}
+
+	var changed []string
+	for _, field := range fields {
+		if !bytes.Equal(aValues[field], bValues[field]) {
+			changed = append(changed, field)
+		}
+	}
+	return changed, nil
+}
+
+// fieldValues returns the JSON representation of all fields, including the custom fields.
+func fieldValues(fields *IssueFields) (map[string]json.RawMessage, error) {
+	values := map[string]json.RawMessage{}
+	if fields == nil {
+		return values, nil
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		return nil, err
+	}
+	err = json.Unmarshal(b, &values)
+	return values, err
+}
@@ -0,0 +1,133 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+	"time"
+)
+
+// testSnapshotUpdated returns the updated timestamp of the snapshots of the tests.
+func testSnapshotUpdated() *Time {
+	updated := Time(time.Date(2016, 4, 6, 2, 36, 53, 594000000, time.FixedZone("", -7*60*60)))
+	return &updated
+}
+
+func TestIssueService_UpdateIfUnchanged(t *testing.T) {
+	setup()
+	defer teardown()
+	updated := false
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			testRequestURL(t, r, "/rest/api/2/issue/EX-1?fields=updated")
+			fmt.Fprint(w, `{"key":"EX-1","fields":{"summary":"Summary","updated":"2016-04-06T02:36:53.594-0700"}}`)
+		case "PUT":
+			updated = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	snapshot := &Issue{Key: "EX-1", Fields: &IssueFields{Summary: "Summary", Updated: testSnapshotUpdated()}}
+	if _, err := testClient.Issue.UpdateIfUnchanged(snapshot, new(UpdateIssueRequest).SetField("summary", "New"), nil); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if !updated {
+		t.Error("Expected the issue to be updated")
+	}
+}
+
+func TestIssueService_UpdateIfUnchanged_Conflict(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			fmt.Fprint(w, `{"key":"EX-1","fields":{"summary":"Changed","updated":"2016-04-07T10:00:00.000-0700"}}`)
+		case "PUT":
+			t.Error("Expected no update")
+		}
+	})
+
+	snapshot := &Issue{Key: "EX-1", Fields: &IssueFields{Summary: "Summary", Updated: testSnapshotUpdated()}}
+	_, err := testClient.Issue.UpdateIfUnchanged(snapshot, new(UpdateIssueRequest).SetField("summary", "New"), nil)
+	if !IsConflict(err) {
+		t.Fatalf("Expected a conflict. Got %v", err)
+	}
+	conflictErr := err.(*ConflictError)
+	if Time(conflictErr.CurrentUpdated).String() != "2016-04-07T10:00:00.000-0700" || conflictErr.Current.Fields.Summary != "Changed" {
+		t.Errorf("Unexpected conflict %+v", conflictErr)
+	}
+
+	// Only a change of the summary is a conflict
+	_, err = testClient.Issue.UpdateIfUnchanged(snapshot, new(UpdateIssueRequest).SetField("summary", "New"), &UpdateIfUnchangedOptions{Fields: []string{"summary"}})
+	if !IsConflict(err) {
+		t.Fatalf("Expected a conflict. Got %v", err)
+	}
+	if want := "issue EX-1 was modified since 2016-04-06T02:36:53.594-0700: fields summary changed"; err.Error() != want {
+		t.Errorf("Expected %s. Got %s", want, err)
+	}
+}
+
+func TestIssueService_UpdateIfUnchanged_UncheckedFieldChanged(t *testing.T) {
+	setup()
+	defer teardown()
+	updated := false
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			testRequestURL(t, r, "/rest/api/2/issue/EX-1?fields=updated%2Csummary")
+			fmt.Fprint(w, `{"key":"EX-1","fields":{"summary":"Summary","updated":"2016-04-07T10:00:00.000-0700"}}`)
+		case "PUT":
+			updated = true
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	snapshot := &Issue{Key: "EX-1", Fields: &IssueFields{Summary: "Summary", Updated: testSnapshotUpdated()}}
+	opt := &UpdateIfUnchangedOptions{Fields: []string{"summary"}}
+	if _, err := testClient.Issue.UpdateIfUnchanged(snapshot, new(UpdateIssueRequest).SetField("summary", "New"), opt); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if !updated {
+		t.Error("Expected the issue to be updated")
+	}
+}
+
+func TestIssueService_UpdateIfUnchanged_Merge(t *testing.T) {
+	setup()
+	defer teardown()
+	gets := 0
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "GET":
+			gets++
+			fmt.Fprint(w, `{"key":"EX-1","fields":{"summary":"Changed","updated":"2016-04-07T10:00:00.000-0700"}}`)
+		case "PUT":
+			w.WriteHeader(http.StatusNoContent)
+		}
+	})
+
+	merged := false
+	opt := &UpdateIfUnchangedOptions{
+		Merge: func(current *Issue, updateReq *UpdateIssueRequest) (*UpdateIssueRequest, error) {
+			merged = true
+			return updateReq.SetField("summary", current.Fields.Summary+" (merged)"), nil
+		},
+	}
+	snapshot := &Issue{Key: "EX-1", Fields: &IssueFields{Summary: "Summary", Updated: testSnapshotUpdated()}}
+	if _, err := testClient.Issue.UpdateIfUnchanged(snapshot, new(UpdateIssueRequest), opt); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if !merged || gets != 2 {
+		t.Errorf("Expected one merge and two reads. Got merged %v and %d reads", merged, gets)
+	}
+}
+
+func TestIssueService_UpdateIfUnchanged_NoTimestamp(t *testing.T) {
+	setup()
+	defer teardown()
+	if _, err := testClient.Issue.UpdateIfUnchanged(&Issue{Key: "EX-1"}, new(UpdateIssueRequest), nil); err == nil {
+		t.Error("Expected an error for a snapshot without updated timestamp")
+	}
+}
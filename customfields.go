@@ -0,0 +1,232 @@
+package jira
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/trivago/tgo/tcontainer"
+)
+
+// The typed accessors below read and write custom fields in Unknowns by their id, e.g. "customfield_10002".
+// Use a FieldRegistry to look up the id of a field by its name.
+// Getters return the zero value (nil, an empty string, a zero time) if the field is not set or null.
+// If the value of a field does not have the requested type, a *FieldError is returned.
+// Setters store the given values, so they are sent as expected when the issue is created or updated.
+
+// CustomString returns the value of the text custom field id.
+func (i *IssueFields) CustomString(id string) (string, error) {
+	var s string
+	err := i.decodeCustom(id, "a string", &s)
+	return s, err
+}
+
+// SetCustomString sets the value of the text custom field id.
+func (i *IssueFields) SetCustomString(id, value string) {
+	i.setCustom(id, value)
+}
+
+// CustomNumber returns the value of the number custom field id, e.g. story points.
+// It returns nil if the field is not set.
+func (i *IssueFields) CustomNumber(id string) (*float64, error) {
+	var n *float64
+	err := i.decodeCustom(id, "a number", &n)
+	return n, err
+}
+
+// SetCustomNumber sets the value of the number custom field id.
+func (i *IssueFields) SetCustomNumber(id string, value float64) {
+	i.setCustom(id, value)
+}
+
+// CustomOption returns the selected option of the select list or radio button custom field id.
+func (i *IssueFields) CustomOption(id string) (*Option, error) {
+	var o *Option
+	err := i.decodeCustom(id, "an option", &o)
+	return o, err
+}
+
+// SetCustomOption sets the selected option of the select list or radio button custom field id.
+// The option is identified by its Value or ID. A nil option clears the field.
+func (i *IssueFields) SetCustomOption(id string, option *Option) {
+	if option == nil {
+		i.setCustom(id, nil)
+		return
+	}
+	i.setCustom(id, option)
+}
+
+// CustomOptions returns the selected options of the multi select or checkbox custom field id.
+func (i *IssueFields) CustomOptions(id string) ([]*Option, error) {
+	var o []*Option
+	err := i.decodeCustom(id, "a list of options", &o)
+	return o, err
+}
+
+// SetCustomOptions sets the selected options of the multi select or checkbox custom field id.
+func (i *IssueFields) SetCustomOptions(id string, options []*Option) {
+	i.setCustom(id, options)
+}
+
+// CustomCascading returns the selected option of the cascading select custom field id.
+// The selected option of the second level, if any, is the Child of the returned option.
+func (i *IssueFields) CustomCascading(id string) (*Option, error) {
+	var o *Option
+	err := i.decodeCustom(id, "a cascading option", &o)
+	return o, err
+}
+
+// SetCustomCascading sets the selected options of the cascading select custom field id.
+// An empty child only selects the parent option.
+func (i *IssueFields) SetCustomCascading(id, parent, child string) {
+	option := &Option{Value: parent}
+	if child != "" {
+		option.Child = &Option{Value: child}
+	}
+	i.setCustom(id, option)
+}
+
+// CustomUser returns the user of the user picker custom field id.
+func (i *IssueFields) CustomUser(id string) (*User, error) {
+	var u *User
+	err := i.decodeCustom(id, "a user", &u)
+	return u, err
+}
+
+// SetCustomUser sets the user of the user picker custom field id. The user is identified by its name.
+// A nil user clears the field.
+func (i *IssueFields) SetCustomUser(id string, user *User) {
+	if user == nil {
+		i.setCustom(id, nil)
+		return
+	}
+	i.setCustom(id, &User{Name: user.Name})
+}
+
+// CustomUsers returns the users of the multi user picker custom field id.
+func (i *IssueFields) CustomUsers(id string) ([]*User, error) {
+	var u []*User
+	err := i.decodeCustom(id, "a list of users", &u)
+	return u, err
+}
+
+// SetCustomUsers sets the users of the multi user picker custom field id. The users are identified by their names, nil users are skipped.
+func (i *IssueFields) SetCustomUsers(id string, users []*User) {
+	names := make([]*User, 0, len(users))
+	for _, u := range users {
+		if u == nil {
+			continue
+		}
+		names = append(names, &User{Name: u.Name})
+	}
+	i.setCustom(id, names)
+}
+
+// CustomDate returns the value of the date picker custom field id.
+// The date is returned as midnight UTC.
+func (i *IssueFields) CustomDate(id string) (time.Time, error) {
+	return i.customTime(id, "a date", dateFormat)
+}
+
+// SetCustomDate sets the value of the date picker custom field id. Only the date of t is used.
+func (i *IssueFields) SetCustomDate(id string, t time.Time) {
+	i.setCustom(id, t.Format(dateFormat))
+}
+
+// CustomDateTime returns the value of the date time picker custom field id.
+func (i *IssueFields) CustomDateTime(id string) (time.Time, error) {
+	return i.customTime(id, "a date and time", timeFormat)
+}
+
+// SetCustomDateTime sets the value of the date time picker custom field id.
+func (i *IssueFields) SetCustomDateTime(id string, t time.Time) {
+	i.setCustom(id, t.Format(timeFormat))
+}
+
+// CustomVersions returns the versions of the version picker custom field id.
+// A single version picker is returned as a list with one version.
+func (i *IssueFields) CustomVersions(id string) ([]*FixVersion, error) {
+	raw, ok, err := i.customJSON(id)
+	if err != nil || !ok {
+		return nil, err
+	}
+	if len(raw) > 0 && raw[0] == '{' {
+		raw = append(append([]byte{'['}, raw...), ']')
+	}
+	var v []*FixVersion
+	err = decodeCustomJSON(id, "a list of versions", raw, &v)
+	return v, err
+}
+
+// SetCustomVersions sets the versions of the version picker custom field id. The versions are identified by their names, nil versions are skipped.
+func (i *IssueFields) SetCustomVersions(id string, versions []*FixVersion) {
+	names := make([]*FixVersion, 0, len(versions))
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		names = append(names, &FixVersion{Name: v.Name})
+	}
+	i.setCustom(id, names)
+}
+
+// customTime parses the value of the custom field id with layout.
+func (i *IssueFields) customTime(id, want, layout string) (time.Time, error) {
+	var s string
+	if err := i.decodeCustom(id, want, &s); err != nil {
+		return time.Time{}, err
+	}
+	if s == "" {
+		return time.Time{}, nil
+	}
+	t, err := time.Parse(layout, s)
+	if err != nil {
+		return time.Time{}, &FieldError{Field: id, Message: fmt.Sprintf("expected %s, got %q", want, s)}
+	}
+	return t, nil
+}
+
+// customJSON returns the JSON representation of the custom field id.
+// ok is false if the field is not set or null.
+func (i *IssueFields) customJSON(id string) (raw []byte, ok bool, err error) {
+	value, found := i.Unknowns[id]
+	if !found || value == nil {
+		return nil, false, nil
+	}
+	raw, err = json.Marshal(value)
+	if err != nil {
+		return nil, false, &FieldError{Field: id, Message: err.Error()}
+	}
+	return raw, string(raw) != "null", nil
+}
+
+// decodeCustom decodes the custom field id into v.
+func (i *IssueFields) decodeCustom(id, want string, v interface{}) error {
+	raw, ok, err := i.customJSON(id)
+	if err != nil || !ok {
+		return err
+	}
+	return decodeCustomJSON(id, want, raw, v)
+}
+
+// decodeCustomJSON decodes raw into v and reports type mismatches as *FieldError.
+func decodeCustomJSON(id, want string, raw []byte, v interface{}) error {
+	err := json.Unmarshal(raw, v)
+	var typeErr *json.UnmarshalTypeError
+	if errors.As(err, &typeErr) {
+		return &FieldError{Field: id, Message: fmt.Sprintf("expected %s, got %s", want, typeErr.Value)}
+	}
+	if err != nil {
+		return &FieldError{Field: id, Message: err.Error()}
+	}
+	return nil
+}
+
+// setCustom stores value as custom field id.
+func (i *IssueFields) setCustom(id string, value interface{}) {
+	if i.Unknowns == nil {
+		i.Unknowns = tcontainer.NewMarshalMap()
+	}
+	i.Unknowns[id] = value
+}
@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+const testCustomFields = `{
+	"customfield_10001": "Some text",
+	"customfield_10002": 5.5,
+	"customfield_10003": {"self": "https://my.jira.com/rest/api/2/customFieldOption/10100", "id": "10100", "value": "Platform"},
+	"customfield_10004": [{"id": "10200", "value": "Red"}, {"id": "10201", "value": "Blue"}],
+	"customfield_10005": {"id": "10300", "value": "Europe", "child": {"id": "10301", "value": "Berlin"}},
+	"customfield_10006": {"name": "jdoe", "displayName": "John Doe"},
+	"customfield_10007": [{"name": "jdoe"}, {"name": "jsmith"}],
+	"customfield_10008": "2017-06-01",
+	"customfield_10009": "2017-06-01T10:30:00.000+0200",
+	"customfield_10010": [{"id": "10400", "name": "1.0"}],
+	"customfield_10011": {"id": "10401", "name": "1.1"},
+	"customfield_10012": null
+}`
+
+func testIssueFieldsWithCustomFields(t *testing.T) *IssueFields {
+	fields := new(IssueFields)
+	if err := json.Unmarshal([]byte(testCustomFields), fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return fields
+}
+
+func TestIssueFields_CustomGetters(t *testing.T) {
+	fields := testIssueFieldsWithCustomFields(t)
+
+	if s, err := fields.CustomString("customfield_10001"); err != nil || s != "Some text" {
+		t.Errorf("CustomString: Expected Some text, got %q (%v)", s, err)
+	}
+	if n, err := fields.CustomNumber("customfield_10002"); err != nil || n == nil || *n != 5.5 {
+		t.Errorf("CustomNumber: Expected 5.5, got %v (%v)", n, err)
+	}
+	if o, err := fields.CustomOption("customfield_10003"); err != nil || o.ID != "10100" || o.Value != "Platform" {
+		t.Errorf("CustomOption: Unexpected option %+v (%v)", o, err)
+	}
+	if o, err := fields.CustomOptions("customfield_10004"); err != nil || len(o) != 2 || o[1].Value != "Blue" {
+		t.Errorf("CustomOptions: Unexpected options %+v (%v)", o, err)
+	}
+	if o, err := fields.CustomCascading("customfield_10005"); err != nil || o.Value != "Europe" || o.Child == nil || o.Child.Value != "Berlin" {
+		t.Errorf("CustomCascading: Unexpected option %+v (%v)", o, err)
+	}
+	if u, err := fields.CustomUser("customfield_10006"); err != nil || u.Name != "jdoe" || u.DisplayName != "John Doe" {
+		t.Errorf("CustomUser: Unexpected user %+v (%v)", u, err)
+	}
+	if u, err := fields.CustomUsers("customfield_10007"); err != nil || len(u) != 2 || u[1].Name != "jsmith" {
+		t.Errorf("CustomUsers: Unexpected users %+v (%v)", u, err)
+	}
+	if d, err := fields.CustomDate("customfield_10008"); err != nil || !d.Equal(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC)) {
+		t.Errorf("CustomDate: Unexpected date %s (%v)", d, err)
+	}
+	if d, err := fields.CustomDateTime("customfield_10009"); err != nil || !d.Equal(time.Date(2017, 6, 1, 8, 30, 0, 0, time.UTC)) {
+		t.Errorf("CustomDateTime: Unexpected time %s (%v)", d, err)
+	}
+	if v, err := fields.CustomVersions("customfield_10010"); err != nil || len(v) != 1 || v[0].Name != "1.0" {
+		t.Errorf("CustomVersions: Unexpected versions %+v (%v)", v, err)
+	}
+	if v, err := fields.CustomVersions("customfield_10011"); err != nil || len(v) != 1 || v[0].Name != "1.1" {
+		t.Errorf("CustomVersions: Unexpected versions of a single version picker %+v (%v)", v, err)
+	}
+}
+
+func TestIssueFields_CustomGetters_NotSet(t *testing.T) {
+	fields := testIssueFieldsWithCustomFields(t)
+
+	for _, id := range []string{"customfield_10012", "customfield_99999"} {
+		if n, err := fields.CustomNumber(id); err != nil || n != nil {
+			t.Errorf("CustomNumber(%s): Expected nil, got %v (%v)", id, n, err)
+		}
+		if o, err := fields.CustomOption(id); err != nil || o != nil {
+			t.Errorf("CustomOption(%s): Expected nil, got %v (%v)", id, o, err)
+		}
+		if d, err := fields.CustomDate(id); err != nil || !d.IsZero() {
+			t.Errorf("CustomDate(%s): Expected zero time, got %s (%v)", id, d, err)
+		}
+	}
+}
+
+func TestIssueFields_CustomGetters_TypeMismatch(t *testing.T) {
+	fields := testIssueFieldsWithCustomFields(t)
+
+	_, err := fields.CustomNumber("customfield_10001")
+	if want := "customfield_10001: expected a number, got string"; err == nil || err.Error() != want {
+		t.Errorf("Expected error %s, got %v", want, err)
+	}
+	if _, err := fields.CustomOption("customfield_10004"); err == nil {
+		t.Error("Expected an error for a list read as single option")
+	}
+	_, err = fields.CustomDate("customfield_10001")
+	if want := `customfield_10001: expected a date, got "Some text"`; err == nil || err.Error() != want {
+		t.Errorf("Expected error %s, got %v", want, err)
+	}
+	if _, isFieldError := err.(*FieldError); !isFieldError {
+		t.Errorf("Expected a *FieldError, got %T", err)
+	}
+}
+
+func TestIssueFields_CustomSetters_RoundTrip(t *testing.T) {
+	fields := &IssueFields{}
+	fields.SetCustomString("customfield_10001", "Some text")
+	fields.SetCustomNumber("customfield_10002", 8)
+	fields.SetCustomOption("customfield_10003", &Option{Value: "Platform"})
+	fields.SetCustomOptions("customfield_10004", []*Option{{Value: "Red"}, {ID: "10201"}})
+	fields.SetCustomCascading("customfield_10005", "Europe", "Berlin")
+	fields.SetCustomUser("customfield_10006", &User{Name: "jdoe", DisplayName: "John Doe"})
+	fields.SetCustomUsers("customfield_10007", []*User{{Name: "jdoe"}, {Name: "jsmith"}})
+	fields.SetCustomDate("customfield_10008", time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+	fields.SetCustomDateTime("customfield_10009", time.Date(2017, 6, 1, 10, 30, 0, 0, time.UTC))
+	fields.SetCustomVersions("customfield_10010", []*FixVersion{{Name: "1.0"}})
+	fields.SetCustomOption("customfield_10012", nil)
+
+	b, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var raw map[string]interface{}
+	json.Unmarshal(b, &raw)
+	if v, ok := raw["customfield_10012"]; !ok || v != nil {
+		t.Errorf("Expected a cleared field to be sent as null, got %v", v)
+	}
+	if v, _ := json.Marshal(raw["customfield_10005"]); string(v) != `{"child":{"value":"Berlin"},"value":"Europe"}` {
+		t.Errorf("Unexpected cascading select %s", v)
+	}
+
+	decoded := new(IssueFields)
+	if err := json.Unmarshal(b, decoded); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if n, _ := decoded.CustomNumber("customfield_10002"); n == nil || *n != 8 {
+		t.Errorf("Expected 8, got %v", n)
+	}
+	if o, _ := decoded.CustomOptions("customfield_10004"); len(o) != 2 || o[0].Value != "Red" || o[1].ID != "10201" {
+		t.Errorf("Unexpected options %+v", o)
+	}
+	if o, _ := decoded.CustomCascading("customfield_10005"); o == nil || o.Child == nil || o.Child.Value != "Berlin" {
+		t.Errorf("Unexpected cascading option %+v", o)
+	}
+	if u, _ := decoded.CustomUser("customfield_10006"); u == nil || u.Name != "jdoe" || u.DisplayName != "" {
+		t.Errorf("Expected only the name of the user to be sent, got %+v", u)
+	}
+	if d, _ := decoded.CustomDateTime("customfield_10009"); !d.Equal(time.Date(2017, 6, 1, 10, 30, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected time %s", d)
+	}
+	if v, _ := decoded.CustomVersions("customfield_10010"); len(v) != 1 || v[0].Name != "1.0" {
+		t.Errorf("Unexpected versions %+v", v)
+	}
+}
+
+func TestIssueFields_CustomSetters_NilElements(t *testing.T) {
+	fields := &IssueFields{}
+	fields.SetCustomUsers("customfield_10007", []*User{nil, {Name: "jdoe"}, nil})
+	fields.SetCustomVersions("customfield_10010", []*FixVersion{{Name: "1.0"}, nil})
+
+	if u, err := fields.CustomUsers("customfield_10007"); err != nil || len(u) != 1 || u[0].Name != "jdoe" {
+		t.Errorf("Expected the nil users to be skipped, got %+v (%v)", u, err)
+	}
+	if v, err := fields.CustomVersions("customfield_10010"); err != nil || len(v) != 1 || v[0].Name != "1.0" {
+		t.Errorf("Expected the nil versions to be skipped, got %+v (%v)", v, err)
+	}
+}
@@ -0,0 +1,236 @@
+package jira
+
+import (
+	"bytes"
+	"encoding/json"
+	"sort"
+	"time"
+)
+
+// DiffIssues compares two versions of an issue and returns the update request that turns original into modified.
+// Typically original is the issue as returned by IssueService.Get and modified a changed copy of it.
+// See DiffIssueFields for the compared fields.
+func DiffIssues(original, modified *Issue) (*UpdateIssueRequest, error) {
+	var originalFields, modifiedFields *IssueFields
+	if original != nil {
+		originalFields = original.Fields
+	}
+	if modified != nil {
+		modifiedFields = modified.Fields
+	}
+	return DiffIssueFields(originalFields, modifiedFields)
+}
+
+// DiffIssueFields compares two versions of the fields of an issue and returns the minimal update request
+// that turns original into modified. Unchanged fields are not part of the request,
+// so concurrent edits of other fields are not overwritten.
+//
+// Summary, description, due date, issue type, priority, assignee, reporter and custom fields (Unknowns)
+// are replaced if they differ. A custom field missing in modified is cleared.
+// Labels, components and fix versions are updated with add and remove operations of the single values,
+// so values added by someone else in the meantime are kept.
+// Read-only fields like status, created or comments are ignored.
+func DiffIssueFields(original, modified *IssueFields) (*UpdateIssueRequest, error) {
+	if original == nil {
+		original = &IssueFields{}
+	}
+	if modified == nil {
+		modified = &IssueFields{}
+	}
+	req := &UpdateIssueRequest{}
+
+	diffString(req, "summary", original.Summary, modified.Summary)
+	diffString(req, "description", original.Description, modified.Description)
+	diffDate(req, "duedate", original.DueDate, modified.DueDate)
+
+	// The issue type can not be removed, an empty type means that it was not loaded
+	if modifiedType := idOrName(modified.Type.ID, modified.Type.Name); modifiedType != (ref{}) {
+		diffRef(req, "issuetype", idOrName(original.Type.ID, original.Type.Name), modifiedType)
+	}
+	diffRef(req, "priority", priorityRef(original.Priority), priorityRef(modified.Priority))
+	diffRef(req, "assignee", userRef(original.Assignee), userRef(modified.Assignee))
+	diffRef(req, "reporter", userRef(original.Reporter), userRef(modified.Reporter))
+
+	diffValues(req, "labels", labelRefs(original.Labels), labelRefs(modified.Labels))
+	diffValues(req, "components", componentRefs(original.Components), componentRefs(modified.Components))
+	diffValues(req, "fixVersions", fixVersionRefs(original.FixVersions), fixVersionRefs(modified.FixVersions))
+
+	if err := diffUnknowns(req, original.Unknowns, modified.Unknowns); err != nil {
+		return nil, err
+	}
+	// The deprecated fields take precedence over the same custom fields in Unknowns
+	diffString(req, justificationField, original.Justification, modified.Justification)
+	diffString(req, rollbackPlanField, original.RollbackPlan, modified.RollbackPlan)
+	return req, nil
+}
+
+// IsEmpty reports if the request contains neither fields nor update operations.
+func (r *UpdateIssueRequest) IsEmpty() bool {
+	return len(r.Fields) == 0 && len(r.Update) == 0
+}
+
+func diffString(req *UpdateIssueRequest, field, original, modified string) {
+	if original != modified {
+		req.SetField(field, modified)
+	}
+}
+
+// diffDate replaces the date if it differs. A missing date clears the field.
+func diffDate(req *UpdateIssueRequest, field string, original, modified *Date) {
+	var originalDate, modifiedDate time.Time
+	if original != nil {
+		originalDate = time.Time(*original)
+	}
+	if modified != nil {
+		modifiedDate = time.Time(*modified)
+	}
+	if originalDate.Equal(modifiedDate) {
+		return
+	}
+	if modifiedDate.IsZero() {
+		req.SetField(field, nil)
+		return
+	}
+	req.SetField(field, Date(modifiedDate))
+}
+
+// ref identifies a value by id or name, as accepted by JIRA in update requests.
+// Labels are plain strings and have no key.
+type ref struct {
+	key   string
+	value string
+}
+
+// payload returns the representation of r in an update request.
+// The zero ref is represented as null, which clears a field.
+func (r ref) payload() interface{} {
+	switch {
+	case r == ref{}:
+		return nil
+	case r.key == "":
+		return r.value
+	}
+	return map[string]string{r.key: r.value}
+}
+
+func diffRef(req *UpdateIssueRequest, field string, original, modified ref) {
+	if original != modified {
+		req.SetField(field, modified.payload())
+	}
+}
+
+// idOrName returns a reference to an object, preferring the id.
+func idOrName(id, name string) ref {
+	switch {
+	case id != "":
+		return ref{key: "id", value: id}
+	case name != "":
+		return ref{key: "name", value: name}
+	}
+	return ref{}
+}
+
+// priorityRef returns the reference to p.
+func priorityRef(p *Priority) ref {
+	if p == nil {
+		return ref{}
+	}
+	return idOrName(p.ID, p.Name)
+}
+
+// userRef returns the reference to u.
+func userRef(u *User) ref {
+	switch {
+	case u == nil:
+		return ref{}
+	case u.Name != "":
+		return ref{key: "name", value: u.Name}
+	case u.Key != "":
+		return ref{key: "key", value: u.Key}
+	}
+	return ref{}
+}
+
+func labelRefs(labels []string) []ref {
+	refs := make([]ref, 0, len(labels))
+	for _, label := range labels {
+		refs = append(refs, ref{value: label})
+	}
+	return refs
+}
+
+func componentRefs(components []*Component) []ref {
+	refs := make([]ref, 0, len(components))
+	for _, c := range components {
+		if c == nil {
+			continue
+		}
+		refs = append(refs, idOrName(c.ID, c.Name))
+	}
+	return refs
+}
+
+func fixVersionRefs(versions []*FixVersion) []ref {
+	refs := make([]ref, 0, len(versions))
+	for _, v := range versions {
+		if v == nil {
+			continue
+		}
+		refs = append(refs, idOrName(v.ID, v.Name))
+	}
+	return refs
+}
+
+// diffValues adds remove operations for values only in original and add operations for values only in modified.
+func diffValues(req *UpdateIssueRequest, field string, original, modified []ref) {
+	inOriginal := make(map[ref]bool, len(original))
+	for _, r := range original {
+		inOriginal[r] = true
+	}
+	inModified := make(map[ref]bool, len(modified))
+	for _, r := range modified {
+		inModified[r] = true
+	}
+
+	for _, r := range original {
+		if !inModified[r] {
+			req.Remove(field, r.payload())
+		}
+	}
+	for _, r := range modified {
+		if !inOriginal[r] {
+			req.Add(field, r.payload())
+			// protection against duplicates in modified
+			inOriginal[r] = true
+		}
+	}
+}
+
+// diffUnknowns replaces all custom fields whose JSON representation differs.
+func diffUnknowns(req *UpdateIssueRequest, original, modified map[string]interface{}) error {
+	keys := make([]string, 0, len(original)+len(modified))
+	for key := range original {
+		keys = append(keys, key)
+	}
+	for key := range modified {
+		if _, ok := original[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		originalJSON, err := json.Marshal(original[key])
+		if err != nil {
+			return err
+		}
+		modifiedJSON, err := json.Marshal(modified[key])
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(originalJSON, modifiedJSON) {
+			req.SetField(key, modified[key])
+		}
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/trivago/tgo/tcontainer"
+)
+
+func TestDiffIssues(t *testing.T) {
+	original := &Issue{
+		Key: "EX-1",
+		Fields: &IssueFields{
+			Type:        IssueType{ID: "1", Name: "Bug"},
+			Summary:     "Summary",
+			Description: "Description",
+			Priority:    &Priority{ID: "3", Name: "Major"},
+			Assignee:    &User{Name: "fred"},
+			Labels:      []string{"a", "b"},
+			Components:  []*Component{{ID: "10", Name: "Backend"}},
+			FixVersions: []*FixVersion{{ID: "100", Name: "1.0"}},
+			Unknowns: tcontainer.MarshalMap{
+				"customfield_1": float64(5),
+				"customfield_2": map[string]interface{}{"value": "red"},
+				"customfield_3": "removed",
+			},
+		},
+	}
+	modified := &Issue{
+		Key: "EX-1",
+		Fields: &IssueFields{
+			Type:        IssueType{ID: "1", Name: "Bug"},
+			Summary:     "New summary",
+			Description: "Description",
+			Priority:    &Priority{ID: "3", Name: "Major"},
+			Assignee:    nil,
+			Labels:      []string{"b", "c", "c"},
+			Components:  []*Component{{ID: "10", Name: "Backend"}, {Name: "Frontend"}},
+			FixVersions: nil,
+			Unknowns: tcontainer.MarshalMap{
+				"customfield_1": 5,
+				"customfield_2": map[string]interface{}{"value": "blue"},
+				"customfield_4": []string{"new"},
+			},
+		},
+	}
+
+	req, err := DiffIssues(original, modified)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	b, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := `{"fields":{"assignee":null,"customfield_2":{"value":"blue"},"customfield_3":null,"customfield_4":["new"],"summary":"New summary"},` +
+		`"update":{"components":[{"add":{"name":"Frontend"}}],"fixVersions":[{"remove":{"id":"100"}}],"labels":[{"remove":"a"},{"add":"c"}]}}`
+	if got := string(b); got != want {
+		t.Errorf("Expected %s. Got %s", want, got)
+	}
+}
+
+func TestDiffIssues_Unchanged(t *testing.T) {
+	fields := &IssueFields{
+		Summary:  "Summary",
+		Labels:   []string{"a"},
+		Reporter: &User{Key: "fred"},
+		Unknowns: tcontainer.MarshalMap{"customfield_1": "x"},
+	}
+	req, err := DiffIssues(&Issue{Fields: fields}, &Issue{Fields: fields})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if !req.IsEmpty() {
+		t.Errorf("Expected an empty request. Got %+v", req)
+	}
+}
+
+func TestDiffIssueFields_IssueTypeAndPriority(t *testing.T) {
+	req, err := DiffIssueFields(
+		&IssueFields{Type: IssueType{ID: "1"}, Priority: &Priority{Name: "Major"}},
+		&IssueFields{Type: IssueType{Name: "Task"}, Priority: nil},
+	)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	b, _ := json.Marshal(req)
+	if want := `{"fields":{"issuetype":{"name":"Task"},"priority":null}}`; string(b) != want {
+		t.Errorf("Expected %s. Got %s", want, b)
+	}
+
+	// a missing issue type in modified is not sent
+	req, _ = DiffIssueFields(&IssueFields{Type: IssueType{ID: "1"}}, &IssueFields{})
+	if !req.IsEmpty() {
+		t.Errorf("Expected an empty request. Got %+v", req)
+	}
+}
+
+func TestDiffIssueFields_DueDate(t *testing.T) {
+	dueDate := Date(time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC))
+	sameDueDate := dueDate
+
+	req, _ := DiffIssueFields(&IssueFields{DueDate: &dueDate}, &IssueFields{DueDate: &sameDueDate})
+	if !req.IsEmpty() {
+		t.Errorf("Expected no changes, got %+v", req)
+	}
+
+	req, _ = DiffIssueFields(&IssueFields{}, &IssueFields{DueDate: &dueDate})
+	if b, _ := json.Marshal(req); string(b) != `{"fields":{"duedate":"2017-06-01"}}` {
+		t.Errorf("Unexpected request %s", b)
+	}
+
+	req, _ = DiffIssueFields(&IssueFields{DueDate: &dueDate}, &IssueFields{})
+	if b, _ := json.Marshal(req); string(b) != `{"fields":{"duedate":null}}` {
+		t.Errorf("Unexpected request %s", b)
+	}
+}
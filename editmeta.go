@@ -0,0 +1,211 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+)
+
+// FieldError describes a problem with a single field of a request.
+type FieldError struct {
+	Field   string
+	Message string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s: %s", e.Field, e.Message)
+}
+
+// FieldErrors lists all problems found by a validation, sorted by field.
+type FieldErrors []*FieldError
+
+func (e FieldErrors) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		msgs = append(msgs, fieldErr.Error())
+	}
+	return strings.Join(msgs, "; ")
+}
+
+// GetEditMetaWithContext returns the fields of the issue issueKey which can be edited by the current user,
+// including their schema, the allowed operations and allowed values.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getEditIssueMeta
+func (s *IssueService) GetEditMetaWithContext(ctx context.Context, issueKey string) (*EditMetaInfo, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/editmeta", issueKey)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	meta := new(EditMetaInfo)
+	resp, err := s.client.Do(req, meta)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return meta, resp, nil
+}
+
+// GetEditMeta wraps GetEditMetaWithContext using the background context.
+func (s *IssueService) GetEditMeta(issueKey string) (*EditMetaInfo, *Response, error) {
+	return s.GetEditMetaWithContext(context.Background(), issueKey)
+}
+
+// ValidateUpdate checks updateReq against the edit metadata before it is sent with UpdateIssue.
+// Every field must be editable, every operation must be allowed for its field,
+// required fields must not be cleared and values must be part of the allowed values of a field, if the field has any.
+// All problems are reported at once as FieldErrors. A nil error means that no problems were found.
+func (m *EditMetaInfo) ValidateUpdate(updateReq *UpdateIssueRequest) error {
+	var errs FieldErrors
+	for field, value := range updateReq.Fields {
+		errs = append(errs, m.validateOperation(field, UpdateOperationSet, value)...)
+	}
+	for field, operations := range updateReq.Update {
+		for _, operation := range operations {
+			for op, value := range operation {
+				errs = append(errs, m.validateOperation(field, op, value)...)
+			}
+		}
+	}
+
+	if len(errs) == 0 {
+		return nil
+	}
+	sort.SliceStable(errs, func(i, j int) bool {
+		return errs[i].Field < errs[j].Field
+	})
+	return errs
+}
+
+// GetFields returns the metadata of all editable fields, keyed by field id.
+func (m *EditMetaInfo) GetFields() (map[string]*FieldMeta, error) {
+	return parseFieldMetas(m.Fields)
+}
+
+// GetField returns the metadata of the field with the id key, e.g. "summary" or "customfield_10002".
+// It returns nil if the field can not be edited.
+func (m *EditMetaInfo) GetField(key string) (*FieldMeta, error) {
+	return parseFieldMeta(m.Fields, key)
+}
+
+func (m *EditMetaInfo) validateOperation(field, op string, value interface{}) FieldErrors {
+	meta, err := m.GetField(field)
+	if err != nil {
+		return FieldErrors{{Field: field, Message: err.Error()}}
+	}
+	if meta == nil {
+		return FieldErrors{{Field: field, Message: "field is not editable"}}
+	}
+	if !meta.HasOperation(op) {
+		return FieldErrors{{Field: field, Message: fmt.Sprintf("operation %s is not allowed, allowed are %s", op, strings.Join(meta.Operations, ", "))}}
+	}
+
+	v, err := normalizeValue(value)
+	if err != nil {
+		return FieldErrors{{Field: field, Message: err.Error()}}
+	}
+	if isEmptyValue(v) {
+		if meta.Required && op == UpdateOperationSet {
+			return FieldErrors{{Field: field, Message: "field is required"}}
+		}
+		return nil
+	}
+
+	// values are only checked if they are added, removing an unknown value does no harm
+	if len(meta.AllowedValues) == 0 || (op != UpdateOperationSet && op != UpdateOperationAdd) {
+		return nil
+	}
+	values, isList := v.([]interface{})
+	if !isList {
+		values = []interface{}{v}
+	}
+	var errs FieldErrors
+	for _, value := range values {
+		if !isAllowedValue(value, meta.AllowedValues) {
+			b, _ := json.Marshal(value)
+			errs = append(errs, &FieldError{Field: field, Message: fmt.Sprintf("value %s is not allowed", b)})
+		}
+	}
+	return errs
+}
+
+// normalizeValue converts value into its generic JSON representation (maps, slices, strings, float64, bool or nil).
+func normalizeValue(value interface{}) (interface{}, error) {
+	b, err := json.Marshal(value)
+	if err != nil {
+		return nil, err
+	}
+	var v interface{}
+	err = json.Unmarshal(b, &v)
+	return v, err
+}
+
+// isEmptyValue reports if the normalized value v clears a field: null, an empty string, an empty list or an empty object.
+func isEmptyValue(v interface{}) bool {
+	switch v := v.(type) {
+	case nil:
+		return true
+	case string:
+		return v == ""
+	case []interface{}:
+		return len(v) == 0
+	case map[string]interface{}:
+		return len(v) == 0
+	}
+	return false
+}
+
+// ref returns the identifying property key of v.
+func (v *AllowedValue) ref(key string) string {
+	switch key {
+	case "id":
+		return v.ID
+	case "key":
+		return v.Key
+	case "name":
+		return v.Name
+	}
+	return v.Value
+}
+
+// allowedValueKeys are the properties identifying an AllowedValue
+var allowedValueKeys = []string{"id", "key", "name", "value"}
+
+// isAllowedValue reports if value references one of allowed.
+// A string or number references an allowed value by any of its identifying properties,
+// an object by the identifying properties it contains. The child of a cascading select is checked as well.
+func isAllowedValue(value interface{}, allowed []AllowedValue) bool {
+	for i := range allowed {
+		a := &allowed[i]
+		switch v := value.(type) {
+		case map[string]interface{}:
+			matched := false
+			for _, key := range allowedValueKeys {
+				if ref, ok := v[key]; ok {
+					if fmt.Sprint(ref) != a.ref(key) {
+						matched = false
+						break
+					}
+					matched = true
+				}
+			}
+			if !matched {
+				continue
+			}
+			if child, hasChild := v["child"]; hasChild && len(a.Children) > 0 {
+				return isAllowedValue(child, a.Children)
+			}
+			return true
+		default:
+			for _, key := range allowedValueKeys {
+				if ref := a.ref(key); ref != "" && ref == fmt.Sprint(v) {
+					return true
+				}
+			}
+		}
+	}
+	return false
+}
@@ -0,0 +1,122 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"testing"
+)
+
+const testEditMeta = `{"fields":{
+	"summary":{"required":true,"schema":{"type":"string","system":"summary"},"name":"Summary","operations":["set"]},
+	"labels":{"required":false,"schema":{"type":"array","items":"string","system":"labels"},"name":"Labels","autoCompleteUrl":"http://www.example.com/jira/rest/api/1.0/labels/suggest?query=","operations":["add","set","remove"]},
+	"priority":{"required":false,"schema":{"type":"priority","system":"priority"},"name":"Priority","operations":["set"],"allowedValues":[{"id":"1","name":"Highest"},{"id":"3","name":"Medium"}]},
+	"components":{"required":false,"schema":{"type":"array","items":"component","system":"components"},"name":"Component/s","operations":["add","set","remove"],"allowedValues":[{"id":"10000","name":"Backend"},{"id":"10001","name":"Frontend"}]},
+	"customfield_10100":{"required":false,"schema":{"type":"option-with-child","custom":"com.atlassian.jira.plugin.system.customfieldtypes:cascadingselect","customId":10100},"name":"Location","operations":["set"],"allowedValues":[{"id":"1","value":"Europe","children":[{"id":"2","value":"Berlin"}]}]}
+}}`
+
+func TestIssueService_GetEditMeta(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1/editmeta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/issue/EX-1/editmeta")
+		fmt.Fprint(w, testEditMeta)
+	})
+
+	meta, _, err := testClient.Issue.GetEditMeta("EX-1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(meta.Fields) != 5 {
+		t.Errorf("Expected 5 fields. Got %d", len(meta.Fields))
+	}
+	if name, _ := meta.Fields.String("priority/name"); name != "Priority" {
+		t.Errorf("Expected name Priority. Got %s", name)
+	}
+}
+
+func testEditMetaInfo(t *testing.T) *EditMetaInfo {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1/editmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testEditMeta)
+	})
+	meta, _, err := testClient.Issue.GetEditMeta("EX-1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return meta
+}
+
+func TestEditMetaInfo_ValidateUpdate(t *testing.T) {
+	meta := testEditMetaInfo(t)
+
+	req := new(UpdateIssueRequest).
+		SetField("summary", "New summary").
+		SetField("priority", &Priority{Name: "Highest"}).
+		SetField("customfield_10100", map[string]interface{}{"value": "Europe", "child": map[string]string{"value": "Berlin"}}).
+		Add("labels", "triaged").
+		Add("components", map[string]string{"id": "10001"}).
+		Remove("components", map[string]string{"name": "Unknown"})
+	if err := meta.ValidateUpdate(req); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestEditMetaInfo_ValidateUpdate_Problems(t *testing.T) {
+	meta := testEditMetaInfo(t)
+
+	req := new(UpdateIssueRequest).
+		SetField("summary", nil).
+		SetField("status", "Done").
+		SetField("priority", map[string]string{"id": "2"}).
+		SetField("customfield_10100", map[string]interface{}{"value": "Europe", "child": map[string]string{"value": "Paris"}}).
+		Add("priority", map[string]string{"id": "1"}).
+		Set("components", []map[string]string{{"name": "Backend"}, {"name": "Mobile"}})
+
+	err := meta.ValidateUpdate(req)
+	errs, ok := err.(FieldErrors)
+	if !ok {
+		t.Fatalf("Expected FieldErrors. Got %v", err)
+	}
+
+	want := map[string]bool{
+		`components: value {"name":"Mobile"} is not allowed`:                                   true,
+		`customfield_10100: value {"child":{"value":"Paris"},"value":"Europe"} is not allowed`: true,
+		`priority: value {"id":"2"} is not allowed`:                                            true,
+		`priority: operation add is not allowed, allowed are set`:                              true,
+		`status: field is not editable`:                                                        true,
+		`summary: field is required`:                                                           true,
+	}
+	if len(errs) != len(want) {
+		t.Errorf("Expected %d errors. Got %d: %s", len(want), len(errs), err)
+	}
+	for _, fieldErr := range errs {
+		if !want[fieldErr.Error()] {
+			t.Errorf("Unexpected error %s", fieldErr)
+		}
+	}
+}
+
+func TestEditMetaInfo_ValidateUpdate_ClearRequired(t *testing.T) {
+	meta := testEditMetaInfo(t)
+	for _, field := range []string{"components", "customfield_10100"} {
+		meta.Fields[field].(map[string]interface{})["required"] = true
+	}
+
+	for field, value := range map[string]interface{}{
+		"summary":           "",
+		"components":        []map[string]string{},
+		"customfield_10100": map[string]string{},
+	} {
+		err := meta.ValidateUpdate(new(UpdateIssueRequest).SetField(field, value))
+		if want := field + ": field is required"; err == nil || err.Error() != want {
+			t.Errorf("Expected %s. Got %v", want, err)
+		}
+	}
+
+	// Empty values of optional fields clear them
+	if err := meta.ValidateUpdate(new(UpdateIssueRequest).SetField("labels", []string{})); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
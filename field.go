@@ -0,0 +1,228 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/trivago/tgo/tcontainer"
+)
+
+// FieldService handles the fields of the JIRA instance / API.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/field
+type FieldService struct {
+	client *Client
+}
+
+// Field represents a system or custom field of a JIRA instance.
+type Field struct {
+	ID          string      `json:"id,omitempty" structs:"id,omitempty"`
+	Key         string      `json:"key,omitempty" structs:"key,omitempty"`
+	Name        string      `json:"name,omitempty" structs:"name,omitempty"`
+	Custom      bool        `json:"custom,omitempty" structs:"custom,omitempty"`
+	Orderable   bool        `json:"orderable,omitempty" structs:"orderable,omitempty"`
+	Navigable   bool        `json:"navigable,omitempty" structs:"navigable,omitempty"`
+	Searchable  bool        `json:"searchable,omitempty" structs:"searchable,omitempty"`
+	ClauseNames []string    `json:"clauseNames,omitempty" structs:"clauseNames,omitempty"`
+	Schema      FieldSchema `json:"schema,omitempty" structs:"schema,omitempty"`
+}
+
+// GetListWithContext gets all system and custom fields of the JIRA instance.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/field-getFields
+func (s *FieldService) GetListWithContext(ctx context.Context) ([]Field, *Response, error) {
+	apiEndpoint := "rest/api/2/field"
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	fieldList := []Field{}
+	resp, err := s.client.Do(req, &fieldList)
+	if err != nil {
+		return nil, resp, err
+	}
+	return fieldList, resp, nil
+}
+
+// GetList wraps GetListWithContext using the background context.
+func (s *FieldService) GetList() ([]Field, *Response, error) {
+	return s.GetListWithContext(context.Background())
+}
+
+// GetRegistryWithContext gets all fields of the JIRA instance and returns a FieldRegistry of them.
+// Use Client.SetFieldRegistry to let the client resolve field names in update requests.
+func (s *FieldService) GetRegistryWithContext(ctx context.Context) (*FieldRegistry, *Response, error) {
+	fieldList, resp, err := s.GetListWithContext(ctx)
+	if err != nil {
+		return nil, resp, err
+	}
+	return NewFieldRegistry(fieldList), resp, nil
+}
+
+// GetRegistry wraps GetRegistryWithContext using the background context.
+func (s *FieldService) GetRegistry() (*FieldRegistry, *Response, error) {
+	return s.GetRegistryWithContext(context.Background())
+}
+
+// FieldRegistry maps the names of the fields of a JIRA instance to their ids and back.
+// Custom fields have different ids on every instance, e.g. "Story Points" may be "customfield_10002" on one
+// and "customfield_10106" on another. A FieldRegistry lets you read and write those fields by their name.
+//
+// Names are compared case insensitive. Names used by more than one field are ambiguous
+// and can not be resolved, the id has to be used for those fields.
+// A FieldRegistry is safe for concurrent use, as it is never modified.
+type FieldRegistry struct {
+	byID   map[string]*Field
+	byName map[string][]*Field
+}
+
+// NewFieldRegistry returns a FieldRegistry of fields, as returned by FieldService.GetList.
+func NewFieldRegistry(fields []Field) *FieldRegistry {
+	r := &FieldRegistry{
+		byID:   make(map[string]*Field, len(fields)),
+		byName: make(map[string][]*Field, len(fields)),
+	}
+	for i := range fields {
+		f := &fields[i]
+		r.byID[f.ID] = f
+		name := strings.ToLower(f.Name)
+		r.byName[name] = append(r.byName[name], f)
+	}
+	return r
+}
+
+// Field returns the field with the id or name nameOrID.
+// Ids take precedence over names.
+func (r *FieldRegistry) Field(nameOrID string) (*Field, error) {
+	if f, ok := r.byID[nameOrID]; ok {
+		return f, nil
+	}
+	fields := r.byName[strings.ToLower(nameOrID)]
+	switch len(fields) {
+	case 0:
+		return nil, fmt.Errorf("unknown field %q", nameOrID)
+	case 1:
+		return fields[0], nil
+	}
+	ids := make([]string, 0, len(fields))
+	for _, f := range fields {
+		ids = append(ids, f.ID)
+	}
+	sort.Strings(ids)
+	return nil, fmt.Errorf("field name %q is ambiguous, it is used by %s", nameOrID, strings.Join(ids, ", "))
+}
+
+// ID returns the id of the field with the name nameOrID, e.g. "customfield_10002" for "Story Points".
+// If nameOrID is already the id of a field, it is returned as is.
+func (r *FieldRegistry) ID(nameOrID string) (string, error) {
+	f, err := r.Field(nameOrID)
+	if err != nil {
+		return "", err
+	}
+	return f.ID, nil
+}
+
+// Name returns the name of the field with the id id, e.g. "Story Points" for "customfield_10002".
+// It returns an empty string if the id is unknown.
+func (r *FieldRegistry) Name(id string) string {
+	if f, ok := r.byID[id]; ok {
+		return f.Name
+	}
+	return ""
+}
+
+// Get returns the value of the field nameOrID of fields, or nil if it is not set.
+// Custom fields are read from fields.Unknowns. System fields are available in the struct fields of IssueFields.
+func (r *FieldRegistry) Get(fields *IssueFields, nameOrID string) (interface{}, error) {
+	id, err := r.ID(nameOrID)
+	if err != nil {
+		return nil, err
+	}
+	if fields == nil {
+		return nil, nil
+	}
+	return fields.Unknowns[id], nil
+}
+
+// Set sets the value of the field nameOrID of fields, e.g. before the issue is created.
+// The value is stored in fields.Unknowns by the id of the field.
+func (r *FieldRegistry) Set(fields *IssueFields, nameOrID string, value interface{}) error {
+	id, err := r.ID(nameOrID)
+	if err != nil {
+		return err
+	}
+	if fields.Unknowns == nil {
+		fields.Unknowns = tcontainer.NewMarshalMap()
+	}
+	fields.Unknowns[id] = value
+	return nil
+}
+
+// ResolveUpdate returns a copy of updateReq with all field names replaced by the ids of the fields.
+// Keys that are neither a known name nor a known id are kept as they are. Ambiguous names are reported as error.
+func (r *FieldRegistry) ResolveUpdate(updateReq *UpdateIssueRequest) (*UpdateIssueRequest, error) {
+	resolved := &UpdateIssueRequest{}
+	for key, value := range updateReq.Fields {
+		id, err := r.resolveKey(key)
+		if err != nil {
+			return nil, err
+		}
+		resolved.SetField(id, value)
+	}
+	for key, operations := range updateReq.Update {
+		id, err := r.resolveKey(key)
+		if err != nil {
+			return nil, err
+		}
+		if resolved.Update == nil {
+			resolved.Update = map[string][]UpdateOperation{}
+		}
+		resolved.Update[id] = append(resolved.Update[id], operations...)
+	}
+	return resolved, nil
+}
+
+// ResolveFields returns a copy of fields with the names of the custom fields in Unknowns replaced by their ids.
+// Keys that are neither a known name nor a known id are kept as they are. Ambiguous names are reported as error.
+func (r *FieldRegistry) ResolveFields(fields *IssueFields) (*IssueFields, error) {
+	resolved := *fields
+	if fields.Unknowns == nil {
+		return &resolved, nil
+	}
+	resolved.Unknowns = make(tcontainer.MarshalMap, len(fields.Unknowns))
+	for key, value := range fields.Unknowns {
+		id, err := r.resolveKey(key)
+		if err != nil {
+			return nil, err
+		}
+		resolved.Unknowns[id] = value
+	}
+	return &resolved, nil
+}
+
+// resolveKey returns the id of the field key. Unknown keys are returned unchanged.
+func (r *FieldRegistry) resolveKey(key string) (string, error) {
+	if _, ok := r.byID[key]; ok {
+		return key, nil
+	}
+	if _, ok := r.byName[strings.ToLower(key)]; !ok {
+		return key, nil
+	}
+	return r.ID(key)
+}
+
+// SetFieldRegistry configures the client to resolve field names with registry.
+// Issues created with IssueService.Create and update requests sent with IssueService.UpdateIssue
+// may then use field names like "Story Points" instead of ids.
+// A nil registry disables the resolution.
+func (c *Client) SetFieldRegistry(registry *FieldRegistry) {
+	c.fieldRegistry = registry
+}
+
+// FieldRegistry returns the FieldRegistry the client was configured with, or nil.
+func (c *Client) FieldRegistry() *FieldRegistry {
+	return c.fieldRegistry
+}
@@ -0,0 +1,211 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+)
+
+const testFieldList = `[
+	{"id": "summary", "key": "summary", "name": "Summary", "custom": false, "orderable": true, "navigable": true, "searchable": true, "clauseNames": ["summary"], "schema": {"type": "string", "system": "summary"}},
+	{"id": "customfield_10002", "key": "customfield_10002", "name": "Story Points", "custom": true, "orderable": true, "navigable": true, "searchable": true, "clauseNames": ["cf[10002]", "Story Points"], "schema": {"type": "number", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:float", "customId": 10002}},
+	{"id": "customfield_10005", "key": "customfield_10005", "name": "Epic Link", "custom": true, "schema": {"type": "any", "custom": "com.pyxis.greenhopper.jira:gh-epic-link", "customId": 10005}},
+	{"id": "customfield_10010", "key": "customfield_10010", "name": "Team", "custom": true, "schema": {"type": "string", "customId": 10010}},
+	{"id": "customfield_10011", "key": "customfield_10011", "name": "team", "custom": true, "schema": {"type": "option", "customId": 10011}}
+]`
+
+func testFieldRegistry(t *testing.T) *FieldRegistry {
+	var fields []Field
+	if err := json.Unmarshal([]byte(testFieldList), &fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return NewFieldRegistry(fields)
+}
+
+func TestFieldService_GetList(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/field", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/field")
+		fmt.Fprint(w, testFieldList)
+	})
+
+	fields, _, err := testClient.Field.GetList()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(fields) != 5 {
+		t.Fatalf("Expected 5 fields, got %d", len(fields))
+	}
+	if f := fields[1]; f.ID != "customfield_10002" || !f.Custom || f.Schema.CustomID != 10002 || len(f.ClauseNames) != 2 {
+		t.Errorf("Unexpected field %+v", f)
+	}
+}
+
+func TestFieldService_GetRegistry(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/field", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, testFieldList)
+	})
+
+	registry, _, err := testClient.Field.GetRegistry()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if id, _ := registry.ID("Epic Link"); id != "customfield_10005" {
+		t.Errorf("Expected customfield_10005, got %s", id)
+	}
+}
+
+func TestFieldRegistry_Resolve(t *testing.T) {
+	registry := testFieldRegistry(t)
+
+	for nameOrID, want := range map[string]string{
+		"Story Points":      "customfield_10002",
+		"story points":      "customfield_10002",
+		"customfield_10002": "customfield_10002",
+		"Summary":           "summary",
+	} {
+		if got, err := registry.ID(nameOrID); err != nil || got != want {
+			t.Errorf("ID(%q): Expected %s, got %s (%v)", nameOrID, want, got, err)
+		}
+	}
+	if name := registry.Name("customfield_10005"); name != "Epic Link" {
+		t.Errorf("Expected Epic Link, got %s", name)
+	}
+	if name := registry.Name("customfield_99999"); name != "" {
+		t.Errorf("Expected no name for an unknown id, got %s", name)
+	}
+
+	if _, err := registry.ID("Sprint"); err == nil {
+		t.Error("Expected an error for an unknown field")
+	}
+	_, err := registry.ID("Team")
+	if want := `field name "Team" is ambiguous, it is used by customfield_10010, customfield_10011`; err == nil || err.Error() != want {
+		t.Errorf("Expected error %s, got %v", want, err)
+	}
+}
+
+func TestFieldRegistry_GetSet(t *testing.T) {
+	registry := testFieldRegistry(t)
+
+	fields := &IssueFields{}
+	if err := registry.Set(fields, "Story Points", 5); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fields.Unknowns["customfield_10002"] != 5 {
+		t.Errorf("Expected the value to be stored by id, got %v", fields.Unknowns)
+	}
+	if value, err := registry.Get(fields, "story points"); err != nil || value != 5 {
+		t.Errorf("Expected 5, got %v (%v)", value, err)
+	}
+	if value, err := registry.Get(fields, "Epic Link"); err != nil || value != nil {
+		t.Errorf("Expected nil for an unset field, got %v (%v)", value, err)
+	}
+}
+
+func TestIssueService_UpdateIssue_FieldRegistry(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/PROJ-9001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		b, _ := ioutil.ReadAll(r.Body)
+		want := `{"fields":{"customfield_10002":8,"unknown":"x"},"update":{"customfield_10005":[{"set":"PROJ-1"}]}}`
+		if got := string(b); got != want+"\n" {
+			t.Errorf("Expected body %s, got %s", want, got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+	testClient.SetFieldRegistry(testFieldRegistry(t))
+
+	req := (&UpdateIssueRequest{}).SetField("Story Points", 8).SetField("unknown", "x").Set("Epic Link", "PROJ-1")
+	if _, err := testClient.Issue.UpdateIssue("PROJ-9001", req); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	// Ambiguous names are not sent
+	req = (&UpdateIssueRequest{}).SetField("Team", "Platform")
+	if _, err := testClient.Issue.UpdateIssue("PROJ-9001", req); err == nil {
+		t.Error("Expected an error for an ambiguous field name")
+	}
+}
+
+func TestIssueService_Create_FieldRegistry(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var payload map[string]map[string]interface{}
+		if err := json.NewDecoder(r.Body).Decode(&payload); err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+		if payload["fields"]["customfield_10002"] != float64(3) || payload["fields"]["summary"] != "Example" {
+			t.Errorf("Expected the field name to be resolved. Got %v", payload)
+		}
+		if _, ok := payload["fields"]["Story Points"]; ok {
+			t.Errorf("Expected no field name to be sent. Got %v", payload)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, `{"id":"10000","key":"PROJ-1"}`)
+	})
+	testClient.SetFieldRegistry(testFieldRegistry(t))
+
+	fields := &IssueFields{Summary: "Example"}
+	fields.Unknowns = map[string]interface{}{"Story Points": 3}
+	if _, _, err := testClient.Issue.Create(&Issue{Fields: fields}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if _, ok := fields.Unknowns["Story Points"]; !ok {
+		t.Error("Expected the fields of the issue to be left unchanged")
+	}
+}
+
+func TestIssueFields_UnknownsOfFormerFields(t *testing.T) {
+	// customfield_10218 and customfield_10220 are regular custom fields of any type
+	var fields IssueFields
+	if err := json.Unmarshal([]byte(`{"summary":"Example","customfield_10218":5,"customfield_10220":{"value":"x"}}`), &fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if value, err := fields.CustomNumber("customfield_10218"); err != nil || value == nil || *value != 5 {
+		t.Errorf("Expected 5, got %v (%v)", value, err)
+	}
+	if fields.Unknowns["customfield_10220"] == nil {
+		t.Errorf("Expected customfield_10220 in the unknowns. Got %v", fields.Unknowns)
+	}
+	if fields.Justification != "" || fields.RollbackPlan != "" {
+		t.Errorf("Expected the deprecated fields to be empty. Got %q and %q", fields.Justification, fields.RollbackPlan)
+	}
+}
+
+func TestIssueFields_DeprecatedFields(t *testing.T) {
+	var fields IssueFields
+	if err := json.Unmarshal([]byte(`{"summary":"Example","customfield_10218":"Needed","customfield_10220":"Revert"}`), &fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if fields.Justification != "Needed" || fields.RollbackPlan != "Revert" {
+		t.Errorf("Expected the deprecated fields to be decoded. Got %q and %q", fields.Justification, fields.RollbackPlan)
+	}
+	if value, err := fields.CustomString("customfield_10218"); err != nil || value != "Needed" {
+		t.Errorf("Expected Needed, got %q (%v)", value, err)
+	}
+
+	fields.Justification = "Changed"
+	b, err := json.Marshal(&fields)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var payload map[string]interface{}
+	if err := json.Unmarshal(b, &payload); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if payload["customfield_10218"] != "Changed" || payload["customfield_10220"] != "Revert" {
+		t.Errorf("Expected the deprecated fields to be sent. Got %s", b)
+	}
+	if _, ok := payload["Justification"]; ok {
+		t.Errorf("Expected no Justification key. Got %s", b)
+	}
+}
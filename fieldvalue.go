@@ -0,0 +1,211 @@
+package jira
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Custom field types that need a representation other than the one of their schema type
+const (
+	customTypeSprint = "com.pyxis.greenhopper.jira:gh-sprint"
+)
+
+// dateLayouts are the accepted input formats of date fields
+var dateLayouts = []string{
+	"2006-01-02",
+	"2 Jan 2006",
+	"2/Jan/06",
+	"2006/01/02",
+}
+
+// dateTimeLayouts are the accepted input formats of datetime fields
+var dateTimeLayouts = []string{
+	"2006-01-02T15:04:05.000-0700",
+	time.RFC3339,
+	"2006-01-02T15:04:05",
+	"2006-01-02T15:04",
+	"2006-01-02 15:04:05",
+	"2006-01-02 15:04",
+}
+
+// fieldValue converts the string values given for the field described by meta
+// into the representation JIRA expects when creating an issue.
+// Fields that are not arrays accept a single value, except for cascading selects (parent and child option)
+// and time tracking (original and remaining estimate).
+func fieldValue(metaProject *MetaProject, meta *FieldMeta, name string, values []string) (interface{}, error) {
+	fieldErr := func(format string, a ...interface{}) error {
+		return &FieldError{Field: name, Message: fmt.Sprintf(format, a...)}
+	}
+
+	if meta.Schema.Custom == customTypeSprint {
+		// A sprint is set by its id, an issue can only be added to a single sprint
+		if len(values) != 1 {
+			return nil, fieldErr("expected a single sprint id, got %d values", len(values))
+		}
+		id, err := strconv.Atoi(strings.TrimSpace(values[0]))
+		if err != nil {
+			return nil, fieldErr("sprint id %q is not a number", values[0])
+		}
+		return id, nil
+	}
+
+	switch meta.Schema.Type {
+	case "array":
+		items := meta.Schema.Items
+		if !elementTypes[items] {
+			// values of unknown types are passed on as they are
+			items = "string"
+		}
+		elements := make([]interface{}, 0, len(values))
+		for _, value := range values {
+			element, err := elementValue(items, value)
+			if err != nil {
+				return nil, &FieldError{Field: name, Message: err.Error()}
+			}
+			elements = append(elements, element)
+		}
+		return typedArray(items, elements), nil
+	case "option-with-child":
+		if len(values) == 0 || len(values) > 2 {
+			return nil, fieldErr("expected a parent and an optional child option, got %d values", len(values))
+		}
+		option := Option{Value: values[0]}
+		if len(values) == 2 {
+			option.Child = &Option{Value: values[1]}
+		}
+		return option, nil
+	case "timetracking":
+		if len(values) == 0 || len(values) > 2 {
+			return nil, fieldErr("expected an original and an optional remaining estimate, got %d values", len(values))
+		}
+		timeTracking := map[string]string{"originalEstimate": values[0]}
+		if len(values) == 2 {
+			timeTracking["remainingEstimate"] = values[1]
+		}
+		return timeTracking, nil
+	case "project":
+		return Project{
+			Name: metaProject.Name,
+			ID:   metaProject.Id,
+		}, nil
+	}
+
+	if !elementTypes[meta.Schema.Type] {
+		return nil, fieldErr("unknown field type %q", meta.Schema.Type)
+	}
+	if len(values) != 1 {
+		return nil, fieldErr("expected a single value, got %d values", len(values))
+	}
+	value, err := elementValue(meta.Schema.Type, values[0])
+	if err != nil {
+		return nil, &FieldError{Field: name, Message: err.Error()}
+	}
+	return value, nil
+}
+
+// elementTypes are the schema types supported by elementValue
+var elementTypes = map[string]bool{
+	"string": true, "any": true, "number": true, "date": true, "datetime": true,
+	"option": true, "component": true, "version": true, "user": true, "priority": true,
+	"issuetype": true, "group": true, "resolution": true, "securitylevel": true,
+}
+
+// elementValue converts value into the representation of the schema type valueType.
+func elementValue(valueType, value string) (interface{}, error) {
+	switch valueType {
+	case "string", "any":
+		// any is used by e.g. the epic link, which is set by the issue key
+		return value, nil
+	case "number":
+		n, err := strconv.ParseFloat(strings.TrimSpace(value), 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return n, nil
+	case "date":
+		t, err := parseTime(dateLayouts, value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date, expected a format like %s", value, dateLayouts[0])
+		}
+		return t.Format("2006-01-02"), nil
+	case "datetime":
+		t, err := parseTime(dateTimeLayouts, value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a date and time, expected a format like %s", value, time.RFC3339)
+		}
+		return t.Format("2006-01-02T15:04:05.000-0700"), nil
+	case "option":
+		return Option{Value: value}, nil
+	case "component":
+		return Component{Name: value}, nil
+	case "version":
+		return FixVersion{Name: value}, nil
+	case "user":
+		return User{Name: value}, nil
+	case "priority":
+		return Priority{Name: value}, nil
+	case "issuetype":
+		return IssueType{Name: value}, nil
+	case "group", "resolution", "securitylevel":
+		return map[string]string{"name": value}, nil
+	}
+	return nil, fmt.Errorf("unknown field type %s", valueType)
+}
+
+// typedArray returns the elements as a slice of their common type, e.g. []Component.
+func typedArray(items string, elements []interface{}) interface{} {
+	switch items {
+	case "string", "any":
+		values := make([]string, 0, len(elements))
+		for _, e := range elements {
+			values = append(values, e.(string))
+		}
+		return values
+	case "number":
+		values := make([]float64, 0, len(elements))
+		for _, e := range elements {
+			values = append(values, e.(float64))
+		}
+		return values
+	case "component":
+		values := make([]Component, 0, len(elements))
+		for _, e := range elements {
+			values = append(values, e.(Component))
+		}
+		return values
+	case "version":
+		values := make([]FixVersion, 0, len(elements))
+		for _, e := range elements {
+			values = append(values, e.(FixVersion))
+		}
+		return values
+	case "user":
+		values := make([]User, 0, len(elements))
+		for _, e := range elements {
+			values = append(values, e.(User))
+		}
+		return values
+	case "option":
+		values := make([]Option, 0, len(elements))
+		for _, e := range elements {
+			values = append(values, e.(Option))
+		}
+		return values
+	}
+	return elements
+}
+
+// parseTime parses value with the first matching layout.
+func parseTime(layouts []string, value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	var err error
+	for _, layout := range layouts {
+		var t time.Time
+		if t, err = time.Parse(layout, value); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, err
+}
@@ -0,0 +1,49 @@
+package jira
+
+import "testing"
+
+func TestElementValue_Dates(t *testing.T) {
+	for value, want := range map[string]string{
+		"2012-10-19":  "2012-10-19",
+		"19 Oct 2012": "2012-10-19",
+		"19/Oct/12":   "2012-10-19",
+		"2012/10/19":  "2012-10-19",
+	} {
+		got, err := elementValue("date", value)
+		if err != nil {
+			t.Errorf("Expected nil error for %q, recieved %s", value, err)
+		} else if got != want {
+			t.Errorf("Expected %s for %q, recieved %s", want, value, got)
+		}
+	}
+
+	for value, want := range map[string]string{
+		"2017-06-01T10:30:00.000+0200": "2017-06-01T10:30:00.000+0200",
+		"2017-06-01T10:30:00+02:00":    "2017-06-01T10:30:00.000+0200",
+		"2017-06-01 10:30":             "2017-06-01T10:30:00.000+0000",
+	} {
+		got, err := elementValue("datetime", value)
+		if err != nil {
+			t.Errorf("Expected nil error for %q, recieved %s", value, err)
+		} else if got != want {
+			t.Errorf("Expected %s for %q, recieved %s", want, value, got)
+		}
+	}
+}
+
+func TestFieldValue_UnknownType(t *testing.T) {
+	meta := &FieldMeta{Key: "customfield_10000", Name: "Checklist", Schema: FieldSchema{Type: "checklist"}}
+	if _, err := fieldValue(&MetaProject{}, meta, "Checklist", []string{"done"}); err == nil {
+		t.Error("Expected an error for an unknown field type")
+	}
+
+	// Elements of unknown types are passed on as strings
+	meta.Schema = FieldSchema{Type: "array", Items: "checklist-item"}
+	value, err := fieldValue(&MetaProject{}, meta, "Checklist", []string{"a", "b"})
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if items, ok := value.([]string); !ok || len(items) != 2 {
+		t.Errorf("Expected 2 strings, recieved %v", value)
+	}
+}
@@ -2,11 +2,13 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
 	"fmt"
 	"io"
 	"io/ioutil"
 	"mime/multipart"
+	"net/http"
 	"net/url"
 	"reflect"
 	"strings"
@@ -30,11 +32,25 @@ type IssueService struct {
 
 // Issue represents a JIRA issue.
 type Issue struct {
-	Expand string       `json:"expand,omitempty" structs:"expand,omitempty"`
-	ID     string       `json:"id,omitempty" structs:"id,omitempty"`
-	Self   string       `json:"self,omitempty" structs:"self,omitempty"`
-	Key    string       `json:"key,omitempty" structs:"key,omitempty"`
-	Fields *IssueFields `json:"fields,omitempty" structs:"fields,omitempty"`
+	Expand    string       `json:"expand,omitempty" structs:"expand,omitempty"`
+	ID        string       `json:"id,omitempty" structs:"id,omitempty"`
+	Self      string       `json:"self,omitempty" structs:"self,omitempty"`
+	Key       string       `json:"key,omitempty" structs:"key,omitempty"`
+	Fields    *IssueFields `json:"fields,omitempty" structs:"fields,omitempty"`
+	Changelog *Changelog   `json:"changelog,omitempty" structs:"changelog,omitempty"`
+	// The following parts are only present if requested with the expand option of the same name
+	RenderedFields tcontainer.MarshalMap  `json:"renderedFields,omitempty" structs:"renderedFields,omitempty"`
+	Names          map[string]string      `json:"names,omitempty" structs:"names,omitempty"`
+	Schema         map[string]FieldSchema `json:"schema,omitempty" structs:"schema,omitempty"`
+	Transitions    []Transition           `json:"transitions,omitempty" structs:"transitions,omitempty"`
+	EditMeta       *EditMetaInfo          `json:"editmeta,omitempty" structs:"editmeta,omitempty"`
+	// Properties contains the issue properties requested with the properties option, by their key
+	Properties map[string]interface{} `json:"properties,omitempty" structs:"properties,omitempty"`
+}
+
+// EditMetaInfo contains the fields of an issue that can be edited, including their schema and allowed operations.
+type EditMetaInfo struct {
+	Fields tcontainer.MarshalMap `json:"fields,omitempty" structs:"fields,omitempty"`
 }
 
 // Attachment represents a JIRA attachment
@@ -43,7 +59,7 @@ type Attachment struct {
 	ID        string `json:"id,omitempty" structs:"id,omitempty"`
 	Filename  string `json:"filename,omitempty" structs:"filename,omitempty"`
 	Author    *User  `json:"author,omitempty" structs:"author,omitempty"`
-	Created   string `json:"created,omitempty" structs:"created,omitempty"`
+	Created   *Time  `json:"created,omitempty" structs:"created,omitempty,omitnested"`
 	Size      int    `json:"size,omitempty" structs:"size,omitempty"`
 	MimeType  string `json:"mimeType,omitempty" structs:"mimeType,omitempty"`
 	Content   string `json:"content,omitempty" structs:"content,omitempty"`
@@ -65,26 +81,17 @@ type Epic struct {
 // Every JIRA issue has several fields attached.
 type IssueFields struct {
 	// TODO Missing fields
-	//	* "timespent": null,
-	//	* "aggregatetimespent": null,
-	//	* "workratio": -1,
 	//	* "lastViewed": null,
-	//	* "timeestimate": null,
-	//	* "aggregatetimeoriginalestimate": null,
-	//	* "timeoriginalestimate": null,
-	//	* "timetracking": {},
-	//	* "aggregatetimeestimate": null,
 	//	* "environment": null,
-	//	* "duedate": null,
 	Type              IssueType     `json:"issuetype" structs:"issuetype"`
 	Project           Project       `json:"project,omitempty" structs:"project,omitempty"`
 	Resolution        *Resolution   `json:"resolution,omitempty" structs:"resolution,omitempty"`
 	Priority          *Priority     `json:"priority,omitempty" structs:"priority,omitempty"`
-	Resolutiondate    string        `json:"resolutiondate,omitempty" structs:"resolutiondate,omitempty"`
-	Created           string        `json:"created,omitempty" structs:"created,omitempty"`
+	Resolutiondate    *Time         `json:"resolutiondate,omitempty" structs:"resolutiondate,omitempty,omitnested"`
+	Created           *Time         `json:"created,omitempty" structs:"created,omitempty,omitnested"`
 	Watches           *Watches      `json:"watches,omitempty" structs:"watches,omitempty"`
 	Assignee          *User         `json:"assignee,omitempty" structs:"assignee,omitempty"`
-	Updated           string        `json:"updated,omitempty" structs:"updated,omitempty"`
+	Updated           *Time         `json:"updated,omitempty" structs:"updated,omitempty,omitnested"`
 	Description       string        `json:"description,omitempty" structs:"description,omitempty"`
 	Summary           string        `json:"summary" structs:"summary"`
 	Creator           *User         `json:"Creator,omitempty" structs:"Creator,omitempty"`
@@ -101,14 +108,48 @@ type IssueFields struct {
 	Subtasks          []*Subtasks   `json:"subtasks,omitempty" structs:"subtasks,omitempty"`
 	Attachments       []*Attachment `json:"attachment,omitempty" structs:"attachment,omitempty"`
 	Epic              *Epic         `json:"epic,omitempty" structs:"epic,omitempty"`
-	Unknowns          tcontainer.MarshalMap
-	DueDate           string `json:"duedate,omitempty" structs:"duedate,omitempty"`
-	Justification     string `json:"customfield_10218,omitempty" structs:"customfield_10218,omitempty"`
-	RollbackPlan      string `json:"customfield_10220,omitempty" structs:"customfield_10220,omitempty"`
+	// Time tracking values in seconds. They are read-only and not sent to JIRA,
+	// use TimeTracking or UpdateIssueRequest.SetTimeTracking to change the estimates.
+	TimeSpent                     *int `json:"timespent,omitempty" structs:"timespent,omitempty"`
+	TimeEstimate                  *int `json:"timeestimate,omitempty" structs:"timeestimate,omitempty"`
+	TimeOriginalEstimate          *int `json:"timeoriginalestimate,omitempty" structs:"timeoriginalestimate,omitempty"`
+	AggregateTimeSpent            *int `json:"aggregatetimespent,omitempty" structs:"aggregatetimespent,omitempty"`
+	AggregateTimeEstimate         *int `json:"aggregatetimeestimate,omitempty" structs:"aggregatetimeestimate,omitempty"`
+	AggregateTimeOriginalEstimate *int `json:"aggregatetimeoriginalestimate,omitempty" structs:"aggregatetimeoriginalestimate,omitempty"`
+	// WorkRatio is the percentage of the original estimate that was logged, or -1 without estimate. Read-only.
+	WorkRatio *int `json:"workratio,omitempty" structs:"workratio,omitempty"`
+	// TimeTracking contains the estimates and the logged time. Only the estimates are sent to JIRA.
+	TimeTracking *TimeTracking `json:"timetracking,omitempty" structs:"timetracking,omitempty"`
+	Unknowns     tcontainer.MarshalMap
+	DueDate      *Date `json:"duedate,omitempty" structs:"duedate,omitempty,omitnested"`
+	// Justification is the text custom field customfield_10218 of a single JIRA instance.
+	// When set, it takes precedence over customfield_10218 in Unknowns.
+	//
+	// Deprecated: use FieldRegistry / CustomString.
+	Justification string `json:"-" structs:"-"`
+	// RollbackPlan is the text custom field customfield_10220 of a single JIRA instance.
+	// When set, it takes precedence over customfield_10220 in Unknowns.
+	//
+	// Deprecated: use FieldRegistry / CustomString.
+	RollbackPlan string `json:"-" structs:"-"`
+}
+
+// The custom fields of the deprecated IssueFields.Justification and IssueFields.RollbackPlan
+const (
+	justificationField = "customfield_10218"
+	rollbackPlanField  = "customfield_10220"
+)
+
+// readOnlyTimeFields are the time tracking fields computed by JIRA, which are rejected in create and update requests
+var readOnlyTimeFields = []string{
+	"timespent", "timeestimate", "timeoriginalestimate",
+	"aggregatetimespent", "aggregatetimeestimate", "aggregatetimeoriginalestimate",
+	"workratio",
 }
 
 // MarshalJSON is a custom JSON marshal function for the IssueFields structs.
 // It handles JIRA custom fields and maps those from / to "Unknowns" key.
+// The read-only time tracking values are left out.
 func (i *IssueFields) MarshalJSON() ([]byte, error) {
 	m := structs.Map(i)
 	unknowns, okay := m["Unknowns"]
@@ -119,6 +160,22 @@ func (i *IssueFields) MarshalJSON() ([]byte, error) {
 		}
 		delete(m, "Unknowns")
 	}
+	if i.Justification != "" {
+		m[justificationField] = i.Justification
+	}
+	if i.RollbackPlan != "" {
+		m[rollbackPlanField] = i.RollbackPlan
+	}
+
+	for _, key := range readOnlyTimeFields {
+		delete(m, key)
+	}
+	delete(m, "timetracking")
+	if i.TimeTracking != nil {
+		if estimates := i.TimeTracking.estimates(); len(estimates) > 0 {
+			m["timetracking"] = estimates
+		}
+	}
 	return json.Marshal(m)
 }
 
@@ -167,6 +224,9 @@ func (i *IssueFields) UnmarshalJSON(data []byte) error {
 	i = (*IssueFields)(aux.Alias)
 	// all the tags found in the struct were removed. Whatever is left are unknowns to struct
 	i.Unknowns = totalMap
+	// The deprecated fields only take text values, on other instances these custom fields can have any type
+	i.Justification, _ = totalMap[justificationField].(string)
+	i.RollbackPlan, _ = totalMap[rollbackPlanField].(string)
 	return nil
 
 }
@@ -236,6 +296,16 @@ type Component struct {
 	Name string `json:"name,omitempty" structs:"name,omitempty"`
 }
 
+// Option represents an option of a select list, radio button or checkbox custom field.
+// Child is the selected option of the second level of a cascading select list.
+type Option struct {
+	Self     string  `json:"self,omitempty" structs:"self,omitempty"`
+	ID       string  `json:"id,omitempty" structs:"id,omitempty"`
+	Value    string  `json:"value,omitempty" structs:"value,omitempty"`
+	Disabled bool    `json:"disabled,omitempty" structs:"disabled,omitempty"`
+	Child    *Option `json:"child,omitempty" structs:"child,omitempty"`
+}
+
 // Status represents the current status of a JIRA issue.
 // Typical status are "Open", "In Progress", "Closed", ...
 // Status can be user defined in every JIRA instance.
@@ -267,6 +337,23 @@ type Progress struct {
 // Time represents the Time definition of JIRA as a time.Time of go
 type Time time.Time
 
+// Date represents the Date definition of JIRA, like the due date of an issue or the release date of a version,
+// as a time.Time of go. A Date has no time of day and is represented as midnight UTC.
+type Date time.Time
+
+// Formats of timestamps and dates in JIRA JSON
+const (
+	timeFormat = "2006-01-02T15:04:05.000-0700"
+	dateFormat = "2006-01-02"
+)
+
+// timeLayouts are the accepted formats of timestamps, the first one is used by JIRA
+var timeLayouts = []string{
+	"2006-01-02T15:04:05.999-0700",
+	time.RFC3339Nano,
+	dateFormat,
+}
+
 // Wrapper struct for search result
 type transitionResult struct {
 	Transitions []Transition `json:"transitions" structs:"transitions"`
@@ -295,9 +382,10 @@ type TransitionPayload struct {
 }
 
 // UnmarshalJSON will transform the JIRA time into a time.Time
-// during the transformation of the JIRA JSON response
+// during the transformation of the JIRA JSON response.
+// null and empty strings are transformed into the zero time. Dates without time of day are accepted as well.
 func (t *Time) UnmarshalJSON(b []byte) error {
-	ti, err := time.Parse("\"2006-01-02T15:04:05.999-0700\"", string(b))
+	ti, err := unmarshalTime(b, timeLayouts)
 	if err != nil {
 		return err
 	}
@@ -305,6 +393,74 @@ func (t *Time) UnmarshalJSON(b []byte) error {
 	return nil
 }
 
+// MarshalJSON will transform the time.Time into a JIRA time.
+// The zero time is transformed into null.
+func (t Time) MarshalJSON() ([]byte, error) {
+	return marshalTime(time.Time(t), timeFormat)
+}
+
+// String returns the time in the format of JIRA, e.g. "2016-04-06T02:36:53.594-0700".
+func (t Time) String() string {
+	return time.Time(t).Format(timeFormat)
+}
+
+// UnmarshalJSON will transform the JIRA date into a time.Time
+// during the transformation of the JIRA JSON response.
+// null and empty strings are transformed into the zero time. For timestamps, only the date is kept.
+func (d *Date) UnmarshalJSON(b []byte) error {
+	ti, err := unmarshalTime(b, append([]string{dateFormat}, timeLayouts...))
+	if err != nil {
+		return err
+	}
+	if !ti.IsZero() {
+		ti = time.Date(ti.Year(), ti.Month(), ti.Day(), 0, 0, 0, 0, time.UTC)
+	}
+	*d = Date(ti)
+	return nil
+}
+
+// MarshalJSON will transform the time.Time into a JIRA date.
+// The zero time is transformed into null.
+func (d Date) MarshalJSON() ([]byte, error) {
+	return marshalTime(time.Time(d), dateFormat)
+}
+
+// String returns the date in the format of JIRA, e.g. "2016-04-06".
+func (d Date) String() string {
+	return time.Time(d).Format(dateFormat)
+}
+
+// unmarshalTime parses the JSON string b with the first matching layout.
+func unmarshalTime(b []byte, layouts []string) (time.Time, error) {
+	if string(b) == "null" {
+		return time.Time{}, nil
+	}
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return time.Time{}, err
+	}
+	if s == "" {
+		return time.Time{}, nil
+	}
+
+	var err error
+	for _, layout := range layouts {
+		var ti time.Time
+		if ti, err = time.Parse(layout, s); err == nil {
+			return ti, nil
+		}
+	}
+	return time.Time{}, err
+}
+
+// marshalTime formats t as JSON string with layout. The zero time is formatted as null.
+func marshalTime(t time.Time, layout string) ([]byte, error) {
+	if t.IsZero() {
+		return []byte("null"), nil
+	}
+	return json.Marshal(t.Format(layout))
+}
+
 // Worklog represents the work log of a JIRA issue.
 // One Worklog contains zero or n WorklogRecords
 // JIRA Wiki: https://confluence.atlassian.com/jira/logging-work-on-an-issue-185729605.html
@@ -317,17 +473,18 @@ type Worklog struct {
 
 // WorklogRecord represents one entry of a Worklog
 type WorklogRecord struct {
-	Self             string `json:"self" structs:"self"`
-	Author           User   `json:"author" structs:"author"`
-	UpdateAuthor     User   `json:"updateAuthor" structs:"updateAuthor"`
-	Comment          string `json:"comment" structs:"comment"`
-	Created          Time   `json:"created" structs:"created"`
-	Updated          Time   `json:"updated" structs:"updated"`
-	Started          Time   `json:"started" structs:"started"`
-	TimeSpent        string `json:"timeSpent" structs:"timeSpent"`
-	TimeSpentSeconds int    `json:"timeSpentSeconds" structs:"timeSpentSeconds"`
-	ID               string `json:"id" structs:"id"`
-	IssueID          string `json:"issueId" structs:"issueId"`
+	Self             string             `json:"self" structs:"self"`
+	Author           User               `json:"author" structs:"author"`
+	UpdateAuthor     User               `json:"updateAuthor" structs:"updateAuthor"`
+	Comment          string             `json:"comment" structs:"comment"`
+	Created          *Time              `json:"created,omitempty" structs:"created,omitempty,omitnested"`
+	Updated          *Time              `json:"updated,omitempty" structs:"updated,omitempty,omitnested"`
+	Started          *Time              `json:"started,omitempty" structs:"started,omitempty,omitnested"`
+	TimeSpent        string             `json:"timeSpent" structs:"timeSpent"`
+	TimeSpentSeconds int                `json:"timeSpentSeconds" structs:"timeSpentSeconds"`
+	ID               string             `json:"id" structs:"id"`
+	IssueID          string             `json:"issueId" structs:"issueId"`
+	Visibility       *CommentVisibility `json:"visibility,omitempty" structs:"visibility,omitempty"`
 }
 
 // Subtasks represents all issues of a parent issue.
@@ -367,12 +524,12 @@ type Comments struct {
 type Comment struct {
 	ID           string            `json:"id,omitempty" structs:"id,omitempty"`
 	Self         string            `json:"self,omitempty" structs:"self,omitempty"`
-	Name         string            `json:"name,omitempty" structs:name,omitempty"`
+	Name         string            `json:"name,omitempty" structs:"name,omitempty"`
 	Author       User              `json:"author,omitempty" structs:"author,omitempty"`
 	Body         string            `json:"body,omitempty" structs:"body,omitempty"`
 	UpdateAuthor User              `json:"updateAuthor,omitempty" structs:"updateAuthor,omitempty"`
-	Updated      string            `json:"updated,omitempty" structs:"updated,omitempty"`
-	Created      string            `json:"created,omitempty" structs:"created,omitempty"`
+	Updated      *Time             `json:"updated,omitempty" structs:"updated,omitempty,omitnested"`
+	Created      *Time             `json:"created,omitempty" structs:"created,omitempty,omitnested"`
 	Visibility   CommentVisibility `json:"visibility,omitempty" structs:"visibility,omitempty"`
 }
 
@@ -382,7 +539,7 @@ type FixVersion struct {
 	ID              string `json:"id,omitempty" structs:"id,omitempty"`
 	Name            string `json:"name,omitempty" structs:"name,omitempty"`
 	ProjectID       int    `json:"projectId,omitempty" structs:"projectId,omitempty"`
-	ReleaseDate     string `json:"releaseDate,omitempty" structs:"releaseDate,omitempty"`
+	ReleaseDate     *Date  `json:"releaseDate,omitempty" structs:"releaseDate,omitempty,omitnested"`
 	Released        *bool  `json:"released,omitempty" structs:"released,omitempty"`
 	Self            string `json:"self,omitempty" structs:"self,omitempty"`
 	UserReleaseDate string `json:"userReleaseDate,omitempty" structs:"userReleaseDate,omitempty"`
@@ -408,28 +565,122 @@ type SearchOptions struct {
 	MaxResults int `url:"maxResults,omitempty"`
 }
 
-// searchResult is only a small wrapper arround the Search (with JQL) method
-// to be able to parse the results
-type searchResult struct {
-	Issues     []Issue `json:"issues" structs:"issues"`
-	StartAt    int     `json:"startAt" structs:"startAt"`
-	MaxResults int     `json:"maxResults" structs:"maxResults"`
-	Total      int     `json:"total" structs:"total"`
+// IssueSearchOptions specifies the optional parameters to the JQL search of issues.
+// Next to the paging parameters of SearchOptions it controls which parts of the issues are returned.
+type IssueSearchOptions struct {
+	// StartAt: The starting index of the returned issues. Base index: 0.
+	StartAt int
+	// MaxResults: The maximum number of issues to return per page. Default: 50.
+	MaxResults int
+	// Expand: Additional information to include in the response, separated by comma.
+	// E.g. "changelog,renderedFields,names,schema"
+	Expand string
+	// Fields: The list of fields to return for each issue. Default: all navigable fields.
+	// E.g. []string{"summary", "status"}, []string{"*all", "-comment"}
+	Fields []string
+	// ValidateQuery: How strictly the JQL query is validated. Valid values: strict, warn, none.
+	// Older JIRA versions accept true and false.
+	ValidateQuery string
+	// Properties: The list of issue properties to return for each issue.
+	Properties []string
+
+	// legacyPaging sends MaxResults even if it is 0, as Search always did with SearchOptions
+	legacyPaging bool
+}
+
+// newIssueSearchOptions converts the paging parameters of options into IssueSearchOptions.
+func newIssueSearchOptions(options *SearchOptions) *IssueSearchOptions {
+	if options == nil {
+		return nil
+	}
+	return &IssueSearchOptions{StartAt: options.StartAt, MaxResults: options.MaxResults, legacyPaging: true}
+}
+
+// searchPostThreshold is the length of the encoded query string of a search
+// above which the search is sent as POST request, to stay clear of URL length limits.
+const searchPostThreshold = 2000
+
+// searchRequest is the body of a search sent as POST request
+type searchRequest struct {
+	JQL           string   `json:"jql"`
+	StartAt       int      `json:"startAt,omitempty"`
+	MaxResults    int      `json:"maxResults,omitempty"`
+	Fields        []string `json:"fields,omitempty"`
+	Expand        []string `json:"expand,omitempty"`
+	ValidateQuery string   `json:"validateQuery,omitempty"`
+	Properties    []string `json:"properties,omitempty"`
+}
+
+// SearchResult represents one page of a search (with JQL).
+// Names and Schema are only filled if requested with IssueSearchOptions.Expand.
+type SearchResult struct {
+	Expand          string                 `json:"expand" structs:"expand"`
+	Issues          []Issue                `json:"issues" structs:"issues"`
+	StartAt         int                    `json:"startAt" structs:"startAt"`
+	MaxResults      int                    `json:"maxResults" structs:"maxResults"`
+	Total           int                    `json:"total" structs:"total"`
+	Names           map[string]string      `json:"names,omitempty" structs:"names,omitempty"`
+	Schema          map[string]FieldSchema `json:"schema,omitempty" structs:"schema,omitempty"`
+	WarningMessages []string               `json:"warningMessages,omitempty" structs:"warningMessages,omitempty"`
+}
+
+// FieldSchema describes the type of an issue field.
+// System fields report their name in System, custom fields report their type in Custom and their id in CustomID.
+type FieldSchema struct {
+	Type     string `json:"type,omitempty" structs:"type,omitempty"`
+	Items    string `json:"items,omitempty" structs:"items,omitempty"`
+	System   string `json:"system,omitempty" structs:"system,omitempty"`
+	Custom   string `json:"custom,omitempty" structs:"custom,omitempty"`
+	CustomID int    `json:"customId,omitempty" structs:"customId,omitempty"`
+}
+
+// GetQueryOptions specifies the optional parameters for the Get Issue methods
+type GetQueryOptions struct {
+	// Fields: The list of fields to return for the issue. Default: all fields.
+	// E.g. []string{"summary", "status"}, []string{"*all", "-comment"}
+	Fields []string `url:"fields,comma,omitempty"`
+	// Expand: Additional information to include in the response, separated by comma.
+	// E.g. "changelog,renderedFields,names,schema,transitions,editmeta"
+	Expand string `url:"expand,omitempty"`
+	// Properties: The list of issue properties to return for the issue.
+	Properties []string `url:"properties,comma,omitempty"`
+	// FieldsByKeys: Whether Fields are referenced by keys instead of ids.
+	FieldsByKeys bool `url:"fieldsByKeys,omitempty"`
+	// UpdateHistory: Whether the issue is added to the "recently viewed" list of the user.
+	UpdateHistory bool `url:"updateHistory,omitempty"`
 }
 
 // CustomFields represents custom fields of JIRA
 // This can heavily differ between JIRA instances
 type CustomFields map[string]string
 
-// Get returns a full representation of the issue for the given issue key.
+// GetWithContext returns a full representation of the issue for the given issue key.
 // JIRA will attempt to identify the issue by the issueIdOrKey path parameter.
 // This can be an issue id, or an issue key.
 // If the issue cannot be found via an exact match, JIRA will also look for the issue in a case-insensitive way, or by looking to see if the issue was moved.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getIssue
+func (s *IssueService) GetWithContext(ctx context.Context, issueID string) (*Issue, *Response, error) {
+	return s.GetWithOptionsWithContext(ctx, issueID, nil)
+}
+
+// Get wraps GetWithContext using the background context.
 func (s *IssueService) Get(issueID string) (*Issue, *Response, error) {
+	return s.GetWithContext(context.Background(), issueID)
+}
+
+// GetWithOptionsWithContext returns a representation of the issue for the given issue key.
+// The given options can be used to limit the returned fields or to expand parts of the issue, e.g. the changelog.
+// options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getIssue
+func (s *IssueService) GetWithOptionsWithContext(ctx context.Context, issueID string, options *GetQueryOptions) (*Issue, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s", issueID)
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -443,13 +694,18 @@ func (s *IssueService) Get(issueID string) (*Issue, *Response, error) {
 	return issue, resp, nil
 }
 
-// DownloadAttachment returns a Response of an attachment for a given attachmentID.
+// GetWithOptions wraps GetWithOptionsWithContext using the background context.
+func (s *IssueService) GetWithOptions(issueID string, options *GetQueryOptions) (*Issue, *Response, error) {
+	return s.GetWithOptionsWithContext(context.Background(), issueID, options)
+}
+
+// DownloadAttachmentWithContext returns a Response of an attachment for a given attachmentID.
 // The attachment is in the Response.Body of the response.
 // This is an io.ReadCloser.
 // The caller should close the resp.Body.
-func (s *IssueService) DownloadAttachment(attachmentID string) (*Response, error) {
+func (s *IssueService) DownloadAttachmentWithContext(ctx context.Context, attachmentID string) (*Response, error) {
 	apiEndpoint := fmt.Sprintf("secure/attachment/%s/", attachmentID)
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, err
 	}
@@ -462,8 +718,13 @@ func (s *IssueService) DownloadAttachment(attachmentID string) (*Response, error
 	return resp, nil
 }
 
-// PostAttachment uploads r (io.Reader) as an attachment to a given attachmentID
-func (s *IssueService) PostAttachment(attachmentID string, r io.Reader, attachmentName string) (*[]Attachment, *Response, error) {
+// DownloadAttachment wraps DownloadAttachmentWithContext using the background context.
+func (s *IssueService) DownloadAttachment(attachmentID string) (*Response, error) {
+	return s.DownloadAttachmentWithContext(context.Background(), attachmentID)
+}
+
+// PostAttachmentWithContext uploads r (io.Reader) as an attachment to a given attachmentID
+func (s *IssueService) PostAttachmentWithContext(ctx context.Context, attachmentID string, r io.Reader, attachmentName string) (*[]Attachment, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/attachments", attachmentID)
 
 	b := new(bytes.Buffer)
@@ -482,7 +743,7 @@ func (s *IssueService) PostAttachment(attachmentID string, r io.Reader, attachme
 	}
 	writer.Close()
 
-	req, err := s.client.NewMultiPartRequest("POST", apiEndpoint, b)
+	req, err := s.client.NewMultiPartRequestWithContext(ctx, "POST", apiEndpoint, b)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -499,14 +760,30 @@ func (s *IssueService) PostAttachment(attachmentID string, r io.Reader, attachme
 	return attachment, resp, nil
 }
 
-// Create creates an issue or a sub-task from a JSON representation.
+// PostAttachment wraps PostAttachmentWithContext using the background context.
+func (s *IssueService) PostAttachment(attachmentID string, r io.Reader, attachmentName string) (*[]Attachment, *Response, error) {
+	return s.PostAttachmentWithContext(context.Background(), attachmentID, r, attachmentName)
+}
+
+// CreateWithContext creates an issue or a sub-task from a JSON representation.
 // Creating a sub-task is similar to creating a regular issue, with two important differences:
 // The issueType field must correspond to a sub-task issue type and you must provide a parent field in the issue create request containing the id or key of the parent issue.
+// If the client was configured with a FieldRegistry, custom fields in Unknowns may be given by name instead of id.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-createIssues
-func (s *IssueService) Create(issue *Issue) (*Issue, *Response, error) {
+func (s *IssueService) CreateWithContext(ctx context.Context, issue *Issue) (*Issue, *Response, error) {
+	if registry := s.client.fieldRegistry; registry != nil && issue.Fields != nil {
+		fields, err := registry.ResolveFields(issue.Fields)
+		if err != nil {
+			return nil, nil, err
+		}
+		resolved := *issue
+		resolved.Fields = fields
+		issue = &resolved
+	}
+
 	apiEndpoint := "rest/api/2/issue/"
-	req, err := s.client.NewRequest("POST", apiEndpoint, issue)
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, issue)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -529,25 +806,215 @@ func (s *IssueService) Create(issue *Issue) (*Issue, *Response, error) {
 	return responseIssue, resp, nil
 }
 
+// Create wraps CreateWithContext using the background context.
+func (s *IssueService) Create(issue *Issue) (*Issue, *Response, error) {
+	return s.CreateWithContext(context.Background(), issue)
+}
+
+// UpdateIssueRequest represents the payload of an issue update request.
+// Fields replaces the values of the given fields, Update applies operations like "add" or "remove" to the values of fields.
+// A field can be part of either Fields or Update, but not both.
+// Fields are identified by their id, e.g. "summary" or "customfield_10002".
+//
+//	req := new(UpdateIssueRequest).
+//		SetField("summary", "New summary").
+//		Set("assignee", map[string]string{"name": "fred"}).
+//		Add("labels", "triaged").
+//		Remove("components", map[string]string{"name": "Backend"})
 type UpdateIssueRequest struct {
-	Update map[string][]map[string]string `json:"update"`
+	Fields map[string]interface{} `json:"fields,omitempty"`
+	// Update was a map[string][]map[string]string in earlier versions, which could only hold string arguments.
+	// Existing literals need to be converted to UpdateOperation values, e.g. []UpdateOperation{{"add": "triaged"}}.
+	Update map[string][]UpdateOperation `json:"update,omitempty"`
 }
 
-func (s *IssueService) UpdateIssue(issueID string, updateReq *UpdateIssueRequest) (*Response, error) {
-	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s", issueID)
-	req, err := s.client.NewRequest("PUT", apiEndpoint, updateReq)
+// UpdateOperation is a single operation on the value of a field, e.g. {"add": "triaged"}.
+// The key is the operation (set, add, remove or edit), the value the argument of the operation.
+type UpdateOperation map[string]interface{}
+
+// Names of the operations supported in the update section of an UpdateIssueRequest
+const (
+	UpdateOperationSet    = "set"
+	UpdateOperationAdd    = "add"
+	UpdateOperationRemove = "remove"
+	UpdateOperationEdit   = "edit"
+)
+
+// UpdateQueryOptions specifies the optional parameters to the update issue methods
+type UpdateQueryOptions struct {
+	// NotifyUsers: Whether a notification email about the update is sent. Default: true.
+	NotifyUsers *bool `url:"notifyUsers,omitempty"`
+}
+
+// SetField replaces the value of field. It returns r to allow chaining.
+func (r *UpdateIssueRequest) SetField(field string, value interface{}) *UpdateIssueRequest {
+	if r.Fields == nil {
+		r.Fields = map[string]interface{}{}
+	}
+	r.Fields[field] = value
+	return r
+}
+
+// Operation appends the operation op with the argument value for field. It returns r to allow chaining.
+func (r *UpdateIssueRequest) Operation(field, op string, value interface{}) *UpdateIssueRequest {
+	if r.Update == nil {
+		r.Update = map[string][]UpdateOperation{}
+	}
+	r.Update[field] = append(r.Update[field], UpdateOperation{op: value})
+	return r
+}
+
+// Set appends a "set" operation for field, which replaces the value of the field.
+func (r *UpdateIssueRequest) Set(field string, value interface{}) *UpdateIssueRequest {
+	return r.Operation(field, UpdateOperationSet, value)
+}
+
+// Add appends an "add" operation for field, which adds value to a multi-value field like labels or components.
+func (r *UpdateIssueRequest) Add(field string, value interface{}) *UpdateIssueRequest {
+	return r.Operation(field, UpdateOperationAdd, value)
+}
+
+// Remove appends a "remove" operation for field, which removes value from a multi-value field like labels or components.
+func (r *UpdateIssueRequest) Remove(field string, value interface{}) *UpdateIssueRequest {
+	return r.Operation(field, UpdateOperationRemove, value)
+}
+
+// Edit appends an "edit" operation for field, which modifies parts of a complex value like the time tracking or a worklog.
+func (r *UpdateIssueRequest) Edit(field string, value interface{}) *UpdateIssueRequest {
+	return r.Operation(field, UpdateOperationEdit, value)
+}
+
+// UpdateIssueWithOptionsWithContext updates the issue issueID with the fields and update operations of updateReq.
+// If the client was configured with a FieldRegistry, fields may be given by name instead of id.
+// options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-editIssue
+func (s *IssueService) UpdateIssueWithOptionsWithContext(ctx context.Context, issueID string, updateReq *UpdateIssueRequest, options *UpdateQueryOptions) (*Response, error) {
+	if registry := s.client.fieldRegistry; registry != nil {
+		resolved, err := registry.ResolveUpdate(updateReq)
 		if err != nil {
+			return nil, err
+		}
+		updateReq = resolved
+	}
+
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s", issueID)
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "PUT", apiEndpoint, updateReq)
+	if err != nil {
 		return nil, err
 	}
 	return s.client.Do(req, nil)
 }
 
-// AddComment adds a new comment to issueID.
+// UpdateIssueWithOptions wraps UpdateIssueWithOptionsWithContext using the background context.
+func (s *IssueService) UpdateIssueWithOptions(issueID string, updateReq *UpdateIssueRequest, options *UpdateQueryOptions) (*Response, error) {
+	return s.UpdateIssueWithOptionsWithContext(context.Background(), issueID, updateReq, options)
+}
+
+// UpdateIssueWithContext updates the issue issueID with the fields and update operations of updateReq.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-editIssue
+func (s *IssueService) UpdateIssueWithContext(ctx context.Context, issueID string, updateReq *UpdateIssueRequest) (*Response, error) {
+	return s.UpdateIssueWithOptionsWithContext(ctx, issueID, updateReq, nil)
+}
+
+// UpdateIssue wraps UpdateIssueWithContext using the background context.
+func (s *IssueService) UpdateIssue(issueID string, updateReq *UpdateIssueRequest) (*Response, error) {
+	return s.UpdateIssueWithContext(context.Background(), issueID, updateReq)
+}
+
+// UpdateWithOptionsWithContext replaces the fields of an issue with the fields of issue.
+// The issue is identified by its key, or its id if the key is empty.
+// Only the non-empty editable fields of issue are sent, see DiffIssueFields. Read-only fields like status,
+// created or comments are left out, so an issue returned by Get can be modified and passed to Update.
+// Of the fields in Unknowns only custom fields (customfield_*) are sent.
+// Labels, components and fix versions are replaced as a whole.
+// The updated issue is fetched and returned afterwards.
+// options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-editIssue
+func (s *IssueService) UpdateWithOptionsWithContext(ctx context.Context, issue *Issue, options *UpdateQueryOptions) (*Issue, *Response, error) {
+	issueID := issue.Key
+	if issueID == "" {
+		issueID = issue.ID
+	}
+	if issueID == "" {
+		return nil, nil, fmt.Errorf("issue has neither a key nor an id")
+	}
+
+	fields, err := editableFields(issue.Fields)
+	if err != nil {
+		return nil, nil, err
+	}
+	resp, err := s.UpdateIssueWithOptionsWithContext(ctx, issueID, &UpdateIssueRequest{Fields: fields}, options)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	return s.GetWithContext(ctx, issueID)
+}
+
+// editableFields returns the values of the non-empty editable fields of fields by their id.
+func editableFields(fields *IssueFields) (map[string]interface{}, error) {
+	if fields != nil && len(fields.Unknowns) > 0 {
+		// Unknowns also contain read-only fields that aren't modelled, like votes or lastViewed.
+		// Only the custom fields of them are sent.
+		custom := *fields
+		custom.Unknowns = tcontainer.NewMarshalMap()
+		for key, value := range fields.Unknowns {
+			if strings.HasPrefix(key, "customfield_") {
+				custom.Unknowns[key] = value
+			}
+		}
+		fields = &custom
+	}
+
+	// Compared to empty fields, every set field is part of the update request
+	req, err := DiffIssueFields(nil, fields)
+	if err != nil {
+		return nil, err
+	}
+
+	values := req.Fields
+	if values == nil {
+		values = map[string]interface{}{}
+	}
+	// The values of multi-value fields are added one by one, they are combined to replace the field
+	for field, operations := range req.Update {
+		list := make([]interface{}, 0, len(operations))
+		for _, op := range operations {
+			list = append(list, op[UpdateOperationAdd])
+		}
+		values[field] = list
+	}
+	return values, nil
+}
+
+// UpdateWithOptions wraps UpdateWithOptionsWithContext using the background context.
+func (s *IssueService) UpdateWithOptions(issue *Issue, options *UpdateQueryOptions) (*Issue, *Response, error) {
+	return s.UpdateWithOptionsWithContext(context.Background(), issue, options)
+}
+
+// UpdateWithContext replaces the fields of an issue with the fields of issue, see UpdateWithOptionsWithContext.
+func (s *IssueService) UpdateWithContext(ctx context.Context, issue *Issue) (*Issue, *Response, error) {
+	return s.UpdateWithOptionsWithContext(ctx, issue, nil)
+}
+
+// Update wraps UpdateWithContext using the background context.
+func (s *IssueService) Update(issue *Issue) (*Issue, *Response, error) {
+	return s.UpdateWithContext(context.Background(), issue)
+}
+
+// AddCommentWithContext adds a new comment to issueID.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-addComment
-func (s *IssueService) AddComment(issueID string, comment *Comment) (*Comment, *Response, error) {
+func (s *IssueService) AddCommentWithContext(ctx context.Context, issueID string, comment *Comment) (*Comment, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/comment", issueID)
-	req, err := s.client.NewRequest("POST", apiEndpoint, comment)
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, comment)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -561,12 +1028,17 @@ func (s *IssueService) AddComment(issueID string, comment *Comment) (*Comment, *
 	return responseComment, resp, nil
 }
 
-// AddLink adds a link between two issues.
+// AddComment wraps AddCommentWithContext using the background context.
+func (s *IssueService) AddComment(issueID string, comment *Comment) (*Comment, *Response, error) {
+	return s.AddCommentWithContext(context.Background(), issueID, comment)
+}
+
+// AddLinkWithContext adds a link between two issues.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issueLink
-func (s *IssueService) AddLink(issueLink *IssueLink) (*Response, error) {
+func (s *IssueService) AddLinkWithContext(ctx context.Context, issueLink *IssueLink) (*Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issueLink")
-	req, err := s.client.NewRequest("POST", apiEndpoint, issueLink)
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, issueLink)
 	if err != nil {
 		return nil, err
 	}
@@ -575,32 +1047,141 @@ func (s *IssueService) AddLink(issueLink *IssueLink) (*Response, error) {
 	return resp, err
 }
 
-// Search will search for tickets according to the jql
+// AddLink wraps AddLinkWithContext using the background context.
+func (s *IssueService) AddLink(issueLink *IssueLink) (*Response, error) {
+	return s.AddLinkWithContext(context.Background(), issueLink)
+}
+
+// SearchWithContext will search for tickets according to the jql
+// Long queries are sent as POST request automatically.
 //
 // JIRA API docs: https://developer.atlassian.com/jiradev/jira-apis/jira-rest-apis/jira-rest-api-tutorials/jira-rest-api-example-query-issues
+func (s *IssueService) SearchWithContext(ctx context.Context, jql string, options *SearchOptions) ([]Issue, *Response, error) {
+	return s.SearchWithOptionsWithContext(ctx, jql, newIssueSearchOptions(options))
+}
+
+// Search wraps SearchWithContext using the background context.
 func (s *IssueService) Search(jql string, options *SearchOptions) ([]Issue, *Response, error) {
-	var u string
-	if options == nil {
-		u = fmt.Sprintf("rest/api/2/search?jql=%s", url.QueryEscape(jql))
-	} else {
-		u = fmt.Sprintf("rest/api/2/search?jql=%s&startAt=%d&maxResults=%d", url.QueryEscape(jql),
-			options.StartAt, options.MaxResults)
+	return s.SearchWithContext(context.Background(), jql, options)
+}
+
+// SearchWithOptionsWithContext will search for tickets according to the jql.
+// options can limit the returned fields or expand parts of the issues, e.g. the changelog. options can be nil.
+// Long queries are sent as POST request automatically.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/search
+func (s *IssueService) SearchWithOptionsWithContext(ctx context.Context, jql string, options *IssueSearchOptions) ([]Issue, *Response, error) {
+	result, resp, err := s.SearchPageWithContext(ctx, jql, options)
+	if result == nil {
+		return []Issue{}, resp, err
 	}
+	return result.Issues, resp, err
+}
 
-	req, err := s.client.NewRequest("GET", u, nil)
+// SearchWithOptions wraps SearchWithOptionsWithContext using the background context.
+func (s *IssueService) SearchWithOptions(jql string, options *IssueSearchOptions) ([]Issue, *Response, error) {
+	return s.SearchWithOptionsWithContext(context.Background(), jql, options)
+}
+
+// SearchPageWithContext will search for tickets according to the jql and returns the complete page of the result.
+// Next to the issues the page contains the paging information and, if requested via options.Expand, the names and schema of all fields.
+// Long queries are sent as POST request automatically.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/search
+func (s *IssueService) SearchPageWithContext(ctx context.Context, jql string, options *IssueSearchOptions) (*SearchResult, *Response, error) {
+	apiEndpoint := "rest/api/2/search"
+
+	// The order of the parameters is kept stable: jql, startAt, maxResults, followed by the optional ones
+	u := fmt.Sprintf("%s?jql=%s", apiEndpoint, url.QueryEscape(jql))
+	if options != nil {
+		u += fmt.Sprintf("&startAt=%d", options.StartAt)
+		if options.MaxResults > 0 || options.legacyPaging {
+			u += fmt.Sprintf("&maxResults=%d", options.MaxResults)
+		}
+		if len(options.Fields) > 0 {
+			u += "&fields=" + url.QueryEscape(strings.Join(options.Fields, ","))
+		}
+		if options.Expand != "" {
+			u += "&expand=" + url.QueryEscape(options.Expand)
+		}
+		if options.ValidateQuery != "" {
+			u += "&validateQuery=" + url.QueryEscape(options.ValidateQuery)
+		}
+		if len(options.Properties) > 0 {
+			u += "&properties=" + url.QueryEscape(strings.Join(options.Properties, ","))
+		}
+	}
+
+	var req *http.Request
+	var err error
+	if len(u) > searchPostThreshold {
+		req, err = s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, newSearchRequest(jql, options))
+		if err == nil {
+			// The search does not modify anything, so it is safe to retry although it is sent as POST request
+			req = markIdempotent(req)
+		}
+	} else {
+		req, err = s.client.NewRequestWithContext(ctx, "GET", u, nil)
+	}
 	if err != nil {
-		return []Issue{}, nil, err
+		return nil, nil, err
 	}
 
-	v := new(searchResult)
+	v := new(SearchResult)
 	resp, err := s.client.Do(req, v)
-	return v.Issues, resp, err
+	if err != nil {
+		return nil, resp, err
+	}
+	return v, resp, nil
+}
+
+// SearchPage wraps SearchPageWithContext using the background context.
+func (s *IssueService) SearchPage(jql string, options *IssueSearchOptions) (*SearchResult, *Response, error) {
+	return s.SearchPageWithContext(context.Background(), jql, options)
+}
+
+// newSearchRequest converts jql and options into the body of a search sent as POST request.
+func newSearchRequest(jql string, options *IssueSearchOptions) *searchRequest {
+	body := &searchRequest{JQL: jql}
+	if options == nil {
+		return body
+	}
+
+	body.StartAt = options.StartAt
+	body.MaxResults = options.MaxResults
+	body.Fields = options.Fields
+	body.ValidateQuery = options.ValidateQuery
+	body.Properties = options.Properties
+	if options.Expand != "" {
+		for _, expand := range strings.Split(options.Expand, ",") {
+			if expand = strings.TrimSpace(expand); expand != "" {
+				body.Expand = append(body.Expand, expand)
+			}
+		}
+	}
+	return body
 }
 
-// GetCustomFields returns a map of customfield_* keys with string values
+// GetCustomFieldsWithContext returns a map of customfield_* keys with string values
+// Structured values are flattened, use the typed accessors of IssueFields like CustomOption to keep their structure.
+func (s *IssueService) GetCustomFieldsWithContext(ctx context.Context, issueID string) (CustomFields, *Response, error) {
+	return s.GetCustomFieldsWithOptionsWithContext(ctx, issueID, nil)
+}
+
+// GetCustomFields wraps GetCustomFieldsWithContext using the background context.
 func (s *IssueService) GetCustomFields(issueID string) (CustomFields, *Response, error) {
+	return s.GetCustomFieldsWithContext(context.Background(), issueID)
+}
+
+// GetCustomFieldsWithOptionsWithContext returns a map of customfield_* keys with string values
+// options can be used to limit the requested fields, e.g. to []string{"customfield_10002"}. options can be nil.
+func (s *IssueService) GetCustomFieldsWithOptionsWithContext(ctx context.Context, issueID string, options *GetQueryOptions) (CustomFields, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s", issueID)
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -633,13 +1214,18 @@ func (s *IssueService) GetCustomFields(issueID string) (CustomFields, *Response,
 	return cf, resp, nil
 }
 
-// GetTransitions gets a list of the transitions possible for this issue by the current user,
+// GetCustomFieldsWithOptions wraps GetCustomFieldsWithOptionsWithContext using the background context.
+func (s *IssueService) GetCustomFieldsWithOptions(issueID string, options *GetQueryOptions) (CustomFields, *Response, error) {
+	return s.GetCustomFieldsWithOptionsWithContext(context.Background(), issueID, options)
+}
+
+// GetTransitionsWithContext gets a list of the transitions possible for this issue by the current user,
 // along with fields that are required and their types.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getTransitions
-func (s *IssueService) GetTransitions(id string) ([]Transition, *Response, error) {
+func (s *IssueService) GetTransitionsWithContext(ctx context.Context, id string) ([]Transition, *Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/transitions?expand=transitions.fields", id)
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -649,11 +1235,16 @@ func (s *IssueService) GetTransitions(id string) ([]Transition, *Response, error
 	return result.Transitions, resp, err
 }
 
-// DoTransition performs a transition on an issue.
+// GetTransitions wraps GetTransitionsWithContext using the background context.
+func (s *IssueService) GetTransitions(id string) ([]Transition, *Response, error) {
+	return s.GetTransitionsWithContext(context.Background(), id)
+}
+
+// DoTransitionWithContext performs a transition on an issue.
 // When performing the transition you can update or set other issue fields.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-doTransition
-func (s *IssueService) DoTransition(ticketID, transitionID string) (*Response, error) {
+func (s *IssueService) DoTransitionWithContext(ctx context.Context, ticketID, transitionID string) (*Response, error) {
 	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/transitions", ticketID)
 
 	payload := CreateTransitionPayload{
@@ -661,19 +1252,24 @@ func (s *IssueService) DoTransition(ticketID, transitionID string) (*Response, e
 			ID: transitionID,
 		},
 	}
-	req, err := s.client.NewRequest("POST", apiEndpoint, payload)
+	req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, payload)
 	if err != nil {
 		return nil, err
 	}
 
 	resp, err := s.client.Do(req, nil)
 	if err != nil {
-		return nil, err
+		return resp, err
 	}
 
 	return resp, nil
 }
 
+// DoTransition wraps DoTransitionWithContext using the background context.
+func (s *IssueService) DoTransition(ticketID, transitionID string) (*Response, error) {
+	return s.DoTransitionWithContext(context.Background(), ticketID, transitionID)
+}
+
 // InitIssueWithMetaAndFields returns Issue with with values from fieldsConfig properly set.
 //  * metaProject should contain metaInformation about the project where the issue should be created.
 //  * metaIssuetype is the MetaInformation about the Issuetype that needs to be created.
@@ -684,60 +1280,51 @@ func (s *IssueService) DoTransition(ticketID, transitionID string) (*Response, e
 //		 error if the key is not found.
 //		 All values will be packed into Unknowns. This is much convenient. If the struct fields needs to be
 //		 configured as well, marshalling and unmarshalling will set the proper fields.
+// See InitIssueWithMetaAndMultiFields for the supported field types and fields with multiple values.
 func InitIssueWithMetaAndFields(metaProject *MetaProject, metaIssuetype *MetaIssueType, fieldsConfig map[string]string) (*Issue, error) {
+	multiFieldsConfig := make(map[string][]string, len(fieldsConfig))
+	for key, value := range fieldsConfig {
+		multiFieldsConfig[key] = []string{value}
+	}
+	return InitIssueWithMetaAndMultiFields(metaProject, metaIssuetype, multiFieldsConfig)
+}
+
+// InitIssueWithMetaAndMultiFields returns Issue with the values from fieldsConfig properly set, like InitIssueWithMetaAndFields.
+// The key of fieldsConfig is the name of the field as seen in the UI or its id, e.g. "customfield_10002".
+// Each field can be given multiple values:
+//  * array fields (labels, components, versions, multi-selects, multi-user pickers, ...) take any number of values
+//  * cascading selects take the parent and optionally the child option
+//  * time tracking takes the original and optionally the remaining estimate, e.g. "2d" and "1d 4h"
+//  * all other fields take exactly one value
+// Numbers (e.g. story points) and sprint ids are parsed, dates and datetimes are parsed and converted to the format of JIRA.
+// Dates are accepted as "2006-01-02", "2 Jan 2006" or "2/Jan/06", datetimes in RFC 3339 or JIRA format or as "2006-01-02 15:04".
+// Options, versions, users, groups and other objects are referenced by their name or value.
+// Values that can not be parsed are reported as *FieldError.
+func InitIssueWithMetaAndMultiFields(metaProject *MetaProject, metaIssuetype *MetaIssueType, fieldsConfig map[string][]string) (*Issue, error) {
 	issue := new(Issue)
 	issueFields := new(IssueFields)
 	issueFields.Unknowns = tcontainer.NewMarshalMap()
 
 	// map the field names the User presented to jira's internal key
 	allFields, _ := metaIssuetype.GetAllFields()
-	for key, value := range fieldsConfig {
+	for key, values := range fieldsConfig {
 		jiraKey, found := allFields[key]
 		if !found {
-			return nil, fmt.Errorf("Key %s is not found in the list of fields.", key)
+			if _, isKey := metaIssuetype.Fields[key]; !isKey {
+				return nil, fmt.Errorf("Key %s is not found in the list of fields.", key)
+			}
+			jiraKey = key
 		}
 
-		valueType, err := metaIssuetype.Fields.String(jiraKey + "/schema/type")
+		meta, err := metaIssuetype.GetField(jiraKey)
 		if err != nil {
 			return nil, err
 		}
-		switch valueType {
-		case "array":
-			elemType, err := metaIssuetype.Fields.String(jiraKey + "/schema/items")
-			if err != nil {
-				return nil, err
-			}
-			switch elemType {
-			case "component":
-				issueFields.Unknowns[jiraKey] = []Component{Component{Name: value}}
-			default:
-				issueFields.Unknowns[jiraKey] = []string{value}
-			}
-		case "string":
-			issueFields.Unknowns[jiraKey] = value
-		case "date":
-			issueFields.Unknowns[jiraKey] = value
-		case "any":
-			// Treat any as string
-			issueFields.Unknowns[jiraKey] = value
-		case "project":
-			issueFields.Unknowns[jiraKey] = Project{
-				Name: metaProject.Name,
-				ID:   metaProject.Id,
-			}
-		case "priority":
-			issueFields.Unknowns[jiraKey] = Priority{Name: value}
-		case "user":
-			issueFields.Unknowns[jiraKey] = User{
-				Name: value,
-			}
-		case "issuetype":
-			issueFields.Unknowns[jiraKey] = IssueType{
-				Name: value,
-			}
-		default:
-			return nil, fmt.Errorf("Unknown issue type encountered: %s for %s", valueType, key)
+		value, err := fieldValue(metaProject, meta, key, values)
+		if err != nil {
+			return nil, err
 		}
+		issueFields.Unknowns[jiraKey] = value
 	}
 
 	issue.Fields = issueFields
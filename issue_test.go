@@ -1,13 +1,16 @@
 package jira
 
 import (
+	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
 	"reflect"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/trivago/tgo/tcontainer"
 )
@@ -31,6 +34,91 @@ func TestIssueService_Get_Success(t *testing.T) {
 	}
 }
 
+func TestIssueService_GetWithContext_Deadline(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		<-r.Context().Done()
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	issue, _, err := testClient.Issue.GetWithContext(ctx, "10002")
+	if issue != nil {
+		t.Errorf("Expected no issue. Got %+v", issue)
+	}
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded error. Got %+v", err)
+	}
+}
+
+func TestIssueService_GetWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/issue/10002?expand=renderedFields%2Cnames%2Cschema%2Ctransitions%2Ceditmeta&fields=summary%2Cstatus&fieldsByKeys=true&properties=prop1%2Cprop2&updateHistory=true")
+
+		fmt.Fprint(w, `{"id":"10002","key":"EX-1","fields":{"summary":"Example","status":{"name":"Open"}},"renderedFields":{"summary":"<p>Example</p>"},"names":{"summary":"Summary","status":"Status"},"schema":{"summary":{"type":"string","system":"summary"}},"transitions":[{"id":"2","name":"Close Issue"}],"editmeta":{"fields":{"summary":{"required":true,"name":"Summary","operations":["set"]}}},"properties":{"prop1":{"team":"core"},"prop2":"reviewed"}}`)
+	})
+
+	opt := &GetQueryOptions{
+		Fields:        []string{"summary", "status"},
+		Expand:        "renderedFields,names,schema,transitions,editmeta",
+		Properties:    []string{"prop1", "prop2"},
+		FieldsByKeys:  true,
+		UpdateHistory: true,
+	}
+	issue, _, err := testClient.Issue.GetWithOptions("10002", opt)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if issue.Fields.Summary != "Example" || issue.Fields.Status.Name != "Open" {
+		t.Errorf("Unexpected fields %+v", issue.Fields)
+	}
+	if got := issue.RenderedFields["summary"]; got != "<p>Example</p>" {
+		t.Errorf("Expected rendered summary. Got %v", got)
+	}
+	if issue.Names["status"] != "Status" {
+		t.Errorf("Expected name of status. Got %v", issue.Names)
+	}
+	if issue.Schema["summary"].Type != "string" {
+		t.Errorf("Expected schema of summary. Got %v", issue.Schema)
+	}
+	if len(issue.Transitions) != 1 || issue.Transitions[0].Name != "Close Issue" {
+		t.Errorf("Expected one transition. Got %v", issue.Transitions)
+	}
+	if issue.Properties["prop2"] != "reviewed" {
+		t.Errorf("Expected property prop2 to be \"reviewed\". Got %v", issue.Properties)
+	}
+	if prop1, ok := issue.Properties["prop1"].(map[string]interface{}); !ok || prop1["team"] != "core" {
+		t.Errorf("Expected property prop1 with team core. Got %v", issue.Properties)
+	}
+	if issue.EditMeta == nil || issue.EditMeta.Fields["summary"] == nil {
+		t.Errorf("Expected editmeta of summary. Got %v", issue.EditMeta)
+	}
+}
+
+func TestIssueService_GetCustomFieldsWithOptions(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/issue/10002?fields=customfield_123")
+		fmt.Fprint(w, `{"id":"10002","key":"EX-1","fields":{"customfield_123":"test"}}`)
+	})
+
+	cf, _, err := testClient.Issue.GetCustomFieldsWithOptions("10002", &GetQueryOptions{Fields: []string{"customfield_123"}})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if cf["customfield_123"] != "test" {
+		t.Error("Expected \"test\" for custom field")
+	}
+}
+
 func TestIssueService_Create(t *testing.T) {
 	setup()
 	defer teardown()
@@ -56,6 +144,155 @@ func TestIssueService_Create(t *testing.T) {
 	}
 }
 
+func TestIssueService_UpdateIssue(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testRequestURL(t, r, "/rest/api/2/issue/10002")
+
+		b, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			t.Errorf("Error reading request body: %s", err)
+		}
+		want := `{"fields":{"customfield_10002":5,"summary":"New summary"},"update":{"assignee":[{"set":{"name":"fred"}}],"components":[{"remove":{"name":"Backend"}}],"labels":[{"add":"triaged"},{"remove":"new"}],"timetracking":[{"edit":{"remainingEstimate":"4d"}}]}}`
+		if got := strings.TrimSpace(string(b)); got != want {
+			t.Errorf("Expected body %s. Got %s", want, got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req := new(UpdateIssueRequest).
+		SetField("summary", "New summary").
+		SetField("customfield_10002", 5).
+		Set("assignee", map[string]string{"name": "fred"}).
+		Add("labels", "triaged").
+		Remove("labels", "new").
+		Remove("components", map[string]string{"name": "Backend"}).
+		Edit("timetracking", map[string]string{"remainingEstimate": "4d"})
+	resp, err := testClient.Issue.UpdateIssue("10002", req)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status code 204. Got %d", resp.StatusCode)
+	}
+}
+
+func TestIssueService_UpdateIssueWithOptions_NotifyUsers(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testRequestURL(t, r, "/rest/api/2/issue/10002?notifyUsers=false")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	notify := false
+	req := new(UpdateIssueRequest).Add("labels", "silent")
+	if _, err := testClient.Issue.UpdateIssueWithOptions("10002", req, &UpdateQueryOptions{NotifyUsers: &notify}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestIssueService_Update(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("Error reading request body: %s", err)
+			}
+			var payload map[string]map[string]interface{}
+			if err := json.Unmarshal(b, &payload); err != nil {
+				t.Errorf("Error given: %s", err)
+			}
+			if payload["fields"]["summary"] != "Updated" || payload["fields"]["customfield_10002"] != "x" {
+				t.Errorf("Unexpected payload %s", b)
+			}
+			if labels, ok := payload["fields"]["labels"].([]interface{}); !ok || len(labels) != 2 || labels[1] != "b" {
+				t.Errorf("Expected the labels to be replaced. Got %s", b)
+			}
+			for _, field := range []string{"status", "created", "comment", "description"} {
+				if _, ok := payload["fields"][field]; ok {
+					t.Errorf("Expected %s not to be sent. Got %s", field, b)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "GET":
+			fmt.Fprint(w, `{"id":"10002","key":"EX-1","fields":{"summary":"Updated"}}`)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	created := Time(time.Now())
+	i := &Issue{
+		Key: "EX-1",
+		Fields: &IssueFields{
+			Summary:  "Updated",
+			Labels:   []string{"a", "b"},
+			Status:   &Status{Name: "Open"},
+			Created:  &created,
+			Comments: &Comments{Comments: []*Comment{{Body: "Hello"}}},
+			Unknowns: tcontainer.MarshalMap{"customfield_10002": "x"},
+		},
+	}
+	issue, _, err := testClient.Issue.Update(i)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if issue.Fields.Summary != "Updated" {
+		t.Errorf("Expected updated issue. Got %+v", issue.Fields)
+	}
+
+	if _, _, err := testClient.Issue.Update(&Issue{}); err == nil {
+		t.Error("Expected an error for an issue without key and id")
+	}
+}
+
+func TestIssueService_Update_IssueFromGet(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/EX-1", func(w http.ResponseWriter, r *http.Request) {
+		switch r.Method {
+		case "PUT":
+			b, err := ioutil.ReadAll(r.Body)
+			if err != nil {
+				t.Errorf("Error reading request body: %s", err)
+			}
+			var payload map[string]map[string]interface{}
+			if err := json.Unmarshal(b, &payload); err != nil {
+				t.Errorf("Error given: %s", err)
+			}
+			if payload["fields"]["summary"] != "Updated" || payload["fields"]["customfield_10002"] != 3.0 {
+				t.Errorf("Unexpected payload %s", b)
+			}
+			for _, field := range []string{"lastViewed", "votes", "watches", "creator", "Creator"} {
+				if _, ok := payload["fields"][field]; ok {
+					t.Errorf("Expected %s not to be sent. Got %s", field, b)
+				}
+			}
+			w.WriteHeader(http.StatusNoContent)
+		case "GET":
+			fmt.Fprint(w, `{"id":"10002","key":"EX-1","fields":{"summary":"Example","lastViewed":"2017-06-19T10:00:00.000+0200","votes":{"votes":0,"hasVoted":false},"watches":{"watchCount":1,"isWatching":true},"creator":{"name":"fred"},"customfield_10002":3}}`)
+		default:
+			t.Errorf("Unexpected method %s", r.Method)
+		}
+	})
+
+	i, _, err := testClient.Issue.Get("EX-1")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	i.Fields.Summary = "Updated"
+	if _, _, err := testClient.Issue.Update(i); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
 func TestIssueService_AddComment(t *testing.T) {
 	setup()
 	defer teardown()
@@ -380,6 +617,141 @@ func TestIssueService_Search_WithoutPaging(t *testing.T) {
 	}
 }
 
+func TestIssueService_SearchPage_WithFieldsAndExpand(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/search?jql=project+%3D+EX&startAt=0&maxResults=10&fields=summary%2Cstatus&expand=names%2Cschema&validateQuery=warn&properties=prop1")
+		fmt.Fprint(w, `{"expand":"names,schema","startAt":0,"maxResults":10,"total":1,"issues":[{"id":"10230","key":"EX-1","fields":{"summary":"Bug"}}],"names":{"summary":"Summary","customfield_10002":"Story Points"},"schema":{"summary":{"type":"string","system":"summary"},"customfield_10002":{"type":"number","custom":"com.atlassian.jira.plugin.system.customfieldtypes:float","customId":10002}},"warningMessages":["The field 'foo' does not exist."]}`)
+	})
+
+	opt := &IssueSearchOptions{
+		MaxResults:    10,
+		Fields:        []string{"summary", "status"},
+		Expand:        "names,schema",
+		ValidateQuery: "warn",
+		Properties:    []string{"prop1"},
+	}
+	result, resp, err := testClient.Issue.SearchPage("project = EX", opt)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if resp.Total != 1 {
+		t.Errorf("Total should populate with 1, %v given", resp.Total)
+	}
+	if len(result.Issues) != 1 || result.Issues[0].Fields.Summary != "Bug" {
+		t.Errorf("Expected issue EX-1 with summary. Got %+v", result.Issues)
+	}
+	if result.Names["customfield_10002"] != "Story Points" {
+		t.Errorf("Expected names to be populated. Got %+v", result.Names)
+	}
+	if schema := result.Schema["customfield_10002"]; schema.Type != "number" || schema.CustomID != 10002 {
+		t.Errorf("Expected schema to be populated. Got %+v", result.Schema)
+	}
+	if len(result.WarningMessages) != 1 {
+		t.Errorf("Expected 1 warning message. Got %v", result.WarningMessages)
+	}
+}
+
+func TestIssueService_SearchWithOptions_DefaultMaxResults(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/search?jql=type+%3D+Bug&startAt=0&fields=summary")
+		if _, ok := r.URL.Query()["maxResults"]; ok {
+			t.Errorf("Expected no maxResults parameter, recieved %s", r.URL.RawQuery)
+		}
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"id":"10230","key":"EX-1","fields":{"summary":"Bug"}}]}`)
+	})
+
+	issues, resp, err := testClient.Issue.SearchWithOptions("type = Bug", &IssueSearchOptions{Fields: []string{"summary"}})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if resp.MaxResults != 50 {
+		t.Errorf("MaxResults should populate with 50, %v given", resp.MaxResults)
+	}
+	if len(issues) != 1 {
+		t.Errorf("Expected 1 issue, recieved %d", len(issues))
+	}
+}
+
+func TestIssueService_Search_LongJQLUsesPost(t *testing.T) {
+	setup()
+	defer teardown()
+
+	var keys []string
+	for i := 0; i < 300; i++ {
+		keys = append(keys, fmt.Sprintf("PROJECT-%d", i))
+	}
+	jql := "key in (" + strings.Join(keys, ",") + ")"
+
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+
+		body := new(searchRequest)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+		want := &searchRequest{
+			JQL:        jql,
+			StartAt:    5,
+			MaxResults: 10,
+			Fields:     []string{"summary"},
+			Expand:     []string{"names", "changelog"},
+		}
+		if !reflect.DeepEqual(body, want) {
+			t.Errorf("Expected body %+v. Got %+v", want, body)
+		}
+		fmt.Fprint(w, `{"startAt":5,"maxResults":10,"total":6,"issues":[{"key":"PROJECT-5"}]}`)
+	})
+
+	opt := &IssueSearchOptions{StartAt: 5, MaxResults: 10, Fields: []string{"summary"}, Expand: "names, changelog"}
+	issues, _, err := testClient.Issue.SearchWithOptions(jql, opt)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if len(issues) != 1 {
+		t.Errorf("Expected 1 issue. Got %d", len(issues))
+	}
+}
+
+func TestIssueService_Search_LongJQLIsRetried(t *testing.T) {
+	setup()
+	defer teardown()
+	testClient.SetRetryPolicy(testRetryPolicy())
+
+	jql := "summary ~ \"" + strings.Repeat("x", 2000) + "\""
+	attempts := 0
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		attempts++
+
+		body := new(searchRequest)
+		if err := json.NewDecoder(r.Body).Decode(body); err != nil {
+			t.Errorf("Error given: %s", err)
+		}
+		if body.JQL != jql {
+			t.Errorf("Expected the JQL to be sent on attempt %d. Got %q", attempts, body.JQL)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		fmt.Fprint(w, `{"startAt":0,"maxResults":50,"total":1,"issues":[{"key":"PROJECT-1"}]}`)
+	})
+
+	issues, _, err := testClient.Issue.Search(jql, nil)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if attempts != 2 || len(issues) != 1 {
+		t.Errorf("Expected the search to succeed on the second attempt. Got %d attempts and %d issues", attempts, len(issues))
+	}
+}
+
 func TestIssueService_GetCustomFields(t *testing.T) {
 	setup()
 	defer teardown()
@@ -744,9 +1116,9 @@ func TestInitIssueWithMetaAndFields_DateValueType(t *testing.T) {
 		Fields: fields,
 	}
 
-	expectedCreated := "19 oct 2012"
+	expectedCreated := "2012-10-19"
 	fieldConfig := map[string]string{
-		"Created": expectedCreated,
+		"Created": "19 oct 2012",
 	}
 
 	issue, err := InitIssueWithMetaAndFields(&metaProject, &metaIssueType, fieldConfig)
@@ -935,3 +1307,189 @@ func TestInitIssueWithmetaAndFields_FailureWithUnknownValueType(t *testing.T) {
 	}
 
 }
+
+func TestInitIssueWithMetaAndMultiFields(t *testing.T) {
+	metaProject := MetaProject{
+		Name: "Engineering - Dept",
+		Id:   "ENG",
+	}
+
+	fields := tcontainer.NewMarshalMap()
+	field := func(key, name string, schema map[string]interface{}) {
+		fields[key] = map[string]interface{}{
+			"name":   name,
+			"schema": schema,
+		}
+	}
+	field("labels", "Labels", map[string]interface{}{"type": "array", "items": "string", "system": "labels"})
+	field("fixVersions", "Fix Version/s", map[string]interface{}{"type": "array", "items": "version", "system": "fixVersions"})
+	field("timetracking", "Time tracking", map[string]interface{}{"type": "timetracking", "system": "timetracking"})
+	field("customfield_10001", "Team", map[string]interface{}{"type": "option", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:select"})
+	field("customfield_10002", "Story Points", map[string]interface{}{"type": "number", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:float"})
+	field("customfield_10003", "Location", map[string]interface{}{"type": "option-with-child", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:cascadingselect"})
+	field("customfield_10004", "Reviewers", map[string]interface{}{"type": "array", "items": "user", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:multiuserpicker"})
+	field("customfield_10005", "Sprint", map[string]interface{}{"type": "array", "items": "string", "custom": "com.pyxis.greenhopper.jira:gh-sprint"})
+	field("customfield_10006", "Epic Link", map[string]interface{}{"type": "any", "custom": "com.pyxis.greenhopper.jira:gh-epic-link"})
+	field("customfield_10007", "Go Live", map[string]interface{}{"type": "datetime", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:datetime"})
+	field("customfield_10008", "Approvers", map[string]interface{}{"type": "group", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:grouppicker"})
+
+	metaIssueType := MetaIssueType{
+		Fields: fields,
+	}
+	fieldConfig := map[string][]string{
+		"Labels":            {"backend", "urgent"},
+		"Fix Version/s":     {"1.0", "1.1"},
+		"Time tracking":     {"2d", "1d 4h"},
+		"Team":              {"Platform"},
+		"customfield_10002": {"5.5"},
+		"Location":          {"Europe", "Berlin"},
+		"Reviewers":         {"jdoe", "jsmith"},
+		"Sprint":            {"42"},
+		"Epic Link":         {"ENG-1"},
+		"Go Live":           {"2017-06-01T10:30:00Z"},
+		"Approvers":         {"jira-administrators"},
+	}
+
+	issue, err := InitIssueWithMetaAndMultiFields(&metaProject, &metaIssueType, fieldConfig)
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+
+	b, err := json.Marshal(issue.Fields.Unknowns)
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	var got map[string]interface{}
+	if err := json.Unmarshal(b, &got); err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	var want map[string]interface{}
+	json.Unmarshal([]byte(`{
+		"labels": ["backend", "urgent"],
+		"fixVersions": [{"name": "1.0"}, {"name": "1.1"}],
+		"timetracking": {"originalEstimate": "2d", "remainingEstimate": "1d 4h"},
+		"customfield_10001": {"value": "Platform"},
+		"customfield_10002": 5.5,
+		"customfield_10003": {"value": "Europe", "child": {"value": "Berlin"}},
+		"customfield_10004": [{"name": "jdoe", "avatarUrls": {}}, {"name": "jsmith", "avatarUrls": {}}],
+		"customfield_10005": 42,
+		"customfield_10006": "ENG-1",
+		"customfield_10007": "2017-06-01T10:30:00.000+0000",
+		"customfield_10008": {"name": "jira-administrators"}
+	}`), &want)
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Expected %v recieved %v", want, got)
+	}
+}
+
+func TestInitIssueWithMetaAndMultiFields_ParseErrors(t *testing.T) {
+	fields := tcontainer.NewMarshalMap()
+	fields["customfield_10002"] = map[string]interface{}{
+		"name":   "Story Points",
+		"schema": map[string]interface{}{"type": "number"},
+	}
+	fields["duedate"] = map[string]interface{}{
+		"name":   "Due Date",
+		"schema": map[string]interface{}{"type": "date"},
+	}
+	fields["summary"] = map[string]interface{}{
+		"name":   "Summary",
+		"schema": map[string]interface{}{"type": "string"},
+	}
+	metaIssueType := MetaIssueType{
+		Fields: fields,
+	}
+
+	for wantErr, config := range map[string]map[string][]string{
+		`Story Points: "many" is not a number`:                                  {"Story Points": {"many"}},
+		`Due Date: "tomorrow" is not a date, expected a format like 2006-01-02`: {"Due Date": {"tomorrow"}},
+		`Summary: expected a single value, got 2 values`:                        {"Summary": {"a", "b"}},
+	} {
+		_, err := InitIssueWithMetaAndMultiFields(&MetaProject{}, &metaIssueType, config)
+		if err == nil {
+			t.Errorf("Expected error %s, recieved nil", wantErr)
+			continue
+		}
+		if _, isFieldError := err.(*FieldError); !isFieldError || err.Error() != wantErr {
+			t.Errorf("Expected error %s, recieved %s", wantErr, err)
+		}
+	}
+}
+
+func TestTime_JSON(t *testing.T) {
+	var record WorklogRecord
+	err := json.Unmarshal([]byte(`{"created":"2016-03-16T04:22:37.356-0700","updated":null,"started":"2016-03-16"}`), &record)
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if want := time.Date(2016, 3, 16, 11, 22, 37, 356000000, time.UTC); !time.Time(*record.Created).Equal(want) {
+		t.Errorf("Expected %s recieved %s", want, time.Time(*record.Created))
+	}
+	if record.Updated != nil {
+		t.Errorf("Expected nil for null, recieved %s", record.Updated)
+	}
+	if want := time.Date(2016, 3, 16, 0, 0, 0, 0, time.UTC); !time.Time(*record.Started).Equal(want) {
+		t.Errorf("Expected %s recieved %s", want, time.Time(*record.Started))
+	}
+
+	b, err := json.Marshal(Time(time.Date(2016, 3, 16, 4, 22, 37, 0, time.FixedZone("", -7*60*60))))
+	if err != nil || string(b) != `"2016-03-16T04:22:37.000-0700"` {
+		t.Errorf("Unexpected JSON %s (%v)", b, err)
+	}
+	if b, _ := json.Marshal(Time{}); string(b) != "null" {
+		t.Errorf("Expected the zero time to be null, recieved %s", b)
+	}
+
+	var ti Time
+	if err := json.Unmarshal([]byte(`"yesterday"`), &ti); err == nil {
+		t.Error("Expected an error for an invalid time")
+	}
+}
+
+func TestDate_JSON(t *testing.T) {
+	var version FixVersion
+	if err := json.Unmarshal([]byte(`{"name":"1.0","releaseDate":"2017-06-01"}`), &version); err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if want := time.Date(2017, 6, 1, 0, 0, 0, 0, time.UTC); !time.Time(*version.ReleaseDate).Equal(want) {
+		t.Errorf("Expected %s recieved %s", want, time.Time(*version.ReleaseDate))
+	}
+
+	b, err := json.Marshal(version)
+	if err != nil || string(b) != `{"name":"1.0","releaseDate":"2017-06-01"}` {
+		t.Errorf("Unexpected JSON %s (%v)", b, err)
+	}
+
+	var d Date
+	if err := json.Unmarshal([]byte(`"2017-06-01T23:30:00.000+0200"`), &d); err != nil || d.String() != "2017-06-01" {
+		t.Errorf("Expected the date of a timestamp, recieved %s (%v)", d, err)
+	}
+	if err := json.Unmarshal([]byte(`null`), &d); err != nil || !time.Time(d).IsZero() {
+		t.Errorf("Expected the zero date for null, recieved %s (%v)", d, err)
+	}
+}
+
+func TestIssueFields_TimeRoundTrip(t *testing.T) {
+	var fields IssueFields
+	data := `{"summary":"Summary","issuetype":{},"created":"2016-03-16T04:22:37.356-0700","updated":"2016-03-17T04:22:37.356-0700","duedate":"2016-04-01","resolutiondate":null}`
+	if err := json.Unmarshal([]byte(data), &fields); err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if fields.Resolutiondate != nil {
+		t.Errorf("Expected no resolution date, recieved %s", fields.Resolutiondate)
+	}
+
+	b, err := json.Marshal(&fields)
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	var got map[string]interface{}
+	json.Unmarshal(b, &got)
+	if got["created"] != "2016-03-16T04:22:37.356-0700" || got["updated"] != "2016-03-17T04:22:37.356-0700" || got["duedate"] != "2016-04-01" {
+		t.Errorf("Unexpected JSON %s", b)
+	}
+	if _, ok := got["resolutiondate"]; ok {
+		t.Errorf("Expected no resolution date, recieved %s", b)
+	}
+}
@@ -2,12 +2,18 @@ package jira
 
 import (
 	"bytes"
+	"context"
 	"encoding/json"
+	"encoding/xml"
+	"errors"
 	"fmt"
 	"io"
+	"io/ioutil"
 	"net/http"
 	"net/url"
 	"reflect"
+	"sort"
+	"strings"
 
 	"github.com/google/go-querystring/query"
 )
@@ -23,12 +29,19 @@ type Client struct {
 	// Session storage if the user authentificate with a Session cookie
 	session *Session
 
+	// Retry policy for temporary failures. Nil disables retries.
+	retryPolicy *RetryPolicy
+
+	// Registry used to resolve field names in update requests. Nil disables the resolution.
+	fieldRegistry *FieldRegistry
+
 	// Services used for talking to different parts of the JIRA API.
 	Authentication *AuthenticationService
 	Issue          *IssueService
 	Project        *ProjectService
 	Board          *BoardService
 	Sprint         *SprintService
+	Field          *FieldService
 }
 
 // NewClient returns a new JIRA API client.
@@ -57,15 +70,17 @@ func NewClient(httpClient *http.Client, baseURL string) (*Client, error) {
 	c.Project = &ProjectService{client: c}
 	c.Board = &BoardService{client: c}
 	c.Sprint = &SprintService{client: c}
+	c.Field = &FieldService{client: c}
 
 	return c, nil
 }
 
-// NewRequest creates an API request.
+// NewRequestWithContext creates an API request.
 // A relative URL can be provided in urlStr, in which case it is resolved relative to the baseURL of the Client.
 // Relative URLs should always be specified without a preceding slash.
 // If specified, the value pointed to by body is JSON encoded and included as the request body.
-func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+// The request is bound to ctx. If ctx gets cancelled, the request will be aborted.
+func (c *Client) NewRequestWithContext(ctx context.Context, method, urlStr string, body interface{}) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -82,7 +97,7 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 		}
 	}
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -99,6 +114,11 @@ func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Requ
 	return req, nil
 }
 
+// NewRequest wraps NewRequestWithContext using the background context.
+func (c *Client) NewRequest(method, urlStr string, body interface{}) (*http.Request, error) {
+	return c.NewRequestWithContext(context.Background(), method, urlStr, body)
+}
+
 // addOptions adds the parameters in opt as URL query parameters to s.  opt
 // must be a struct whose fields may contain "url" tags.
 func addOptions(s string, opt interface{}) (string, error) {
@@ -121,11 +141,12 @@ func addOptions(s string, opt interface{}) (string, error) {
 	return u.String(), nil
 }
 
-// NewMultiPartRequest creates an API request including a multi-part file.
+// NewMultiPartRequestWithContext creates an API request including a multi-part file.
 // A relative URL can be provided in urlStr, in which case it is resolved relative to the baseURL of the Client.
 // Relative URLs should always be specified without a preceding slash.
 // If specified, the value pointed to by buf is a multipart form.
-func (c *Client) NewMultiPartRequest(method, urlStr string, buf *bytes.Buffer) (*http.Request, error) {
+// The request is bound to ctx. If ctx gets cancelled, the request will be aborted.
+func (c *Client) NewMultiPartRequestWithContext(ctx context.Context, method, urlStr string, buf *bytes.Buffer) (*http.Request, error) {
 	rel, err := url.Parse(urlStr)
 	if err != nil {
 		return nil, err
@@ -133,7 +154,7 @@ func (c *Client) NewMultiPartRequest(method, urlStr string, buf *bytes.Buffer) (
 
 	u := c.baseURL.ResolveReference(rel)
 
-	req, err := http.NewRequest(method, u.String(), buf)
+	req, err := http.NewRequestWithContext(ctx, method, u.String(), buf)
 	if err != nil {
 		return nil, err
 	}
@@ -151,10 +172,17 @@ func (c *Client) NewMultiPartRequest(method, urlStr string, buf *bytes.Buffer) (
 	return req, nil
 }
 
+// NewMultiPartRequest wraps NewMultiPartRequestWithContext using the background context.
+func (c *Client) NewMultiPartRequest(method, urlStr string, buf *bytes.Buffer) (*http.Request, error) {
+	return c.NewMultiPartRequestWithContext(context.Background(), method, urlStr, buf)
+}
+
 // Do sends an API request and returns the API response.
 // The API response is JSON decoded and stored in the value pointed to by v, or returned as an error if an API error has occurred.
+// The request is cancelled as soon as the context of req is done.
+// Temporary failures are retried according to the RetryPolicy of the Client, see SetRetryPolicy.
 func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
-	httpResp, err := c.client.Do(req)
+	httpResp, err := c.doWithRetry(req)
 	if err != nil {
 		return nil, err
 	}
@@ -178,15 +206,147 @@ func (c *Client) Do(req *http.Request, v interface{}) (*Response, error) {
 
 // CheckResponse checks the API response for errors, and returns them if present.
 // A response is considered an error if it has a status code outside the 200 range.
-// The caller is responsible to analyze the response body.
+// API error responses are returned as *ErrorResponse.
 // The body can contain JSON (if the error is intended) or xml (sometimes JIRA just failes).
+// The body is read completely and replaced, so the caller is still able to analyze it.
 func CheckResponse(r *http.Response) error {
 	if c := r.StatusCode; 200 <= c && c <= 299 {
 		return nil
 	}
 
-	err := fmt.Errorf("Request failed. Please analyze the request body for more details. Status code: %d", r.StatusCode)
-	return err
+	errorResponse := &ErrorResponse{
+		Response:   r,
+		StatusCode: r.StatusCode,
+	}
+	if r.Body != nil {
+		data, err := ioutil.ReadAll(r.Body)
+		r.Body.Close()
+		r.Body = ioutil.NopCloser(bytes.NewReader(data))
+		if err == nil {
+			errorResponse.Body = data
+			errorResponse.parseBody()
+		}
+	}
+	return errorResponse
+}
+
+// ErrorResponse reports an error caused by an API request.
+// JIRA reports errors as JSON document with a list of general error messages
+// and a map of field specific errors:
+//
+//	{"errorMessages":["Issue Does Not Exist"],"errors":{"summary":"Field is required"}}
+//
+// Sometimes JIRA responds with XML or HTML instead (e.g. a proxy or a servlet error page).
+// In this case the status code and the raw body are still available.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#error-responses
+type ErrorResponse struct {
+	// Response is the HTTP response that caused this error
+	Response *http.Response `json:"-"`
+	// StatusCode is the HTTP status code of the response
+	StatusCode int `json:"-"`
+	// ErrorMessages is a list of general error messages
+	ErrorMessages []string `json:"errorMessages,omitempty"`
+	// Errors maps field names to field specific error messages
+	Errors map[string]string `json:"errors,omitempty"`
+	// Body is the raw body of the response
+	Body []byte `json:"-"`
+}
+
+// parseBody fills the messages of e based on the raw body.
+// JSON is the documented format. XML and HTML bodies are reduced to a single message.
+func (e *ErrorResponse) parseBody() {
+	body := bytes.TrimSpace(e.Body)
+	if len(body) == 0 {
+		return
+	}
+
+	if body[0] == '{' {
+		if err := json.Unmarshal(body, e); err == nil {
+			return
+		}
+	}
+
+	if body[0] == '<' {
+		// JIRA servlet errors look like <status><status-code>404</status-code><message>...</message></status>
+		xmlError := struct {
+			Message string `xml:"message"`
+			Title   string `xml:"head>title"`
+		}{}
+		if err := xml.Unmarshal(body, &xmlError); err == nil {
+			if msg := strings.TrimSpace(xmlError.Message); msg != "" {
+				e.ErrorMessages = []string{msg}
+				return
+			}
+			if title := strings.TrimSpace(xmlError.Title); title != "" {
+				e.ErrorMessages = []string{title}
+				return
+			}
+		}
+		// HTML is often not well-formed XML, so the raw body is all we have
+		return
+	}
+
+	// Plain text (e.g. from http.Error)
+	e.ErrorMessages = []string{string(body)}
+}
+
+// Error returns a human readable representation of the error response.
+func (e *ErrorResponse) Error() string {
+	var msg string
+	if e.Response != nil && e.Response.Request != nil {
+		msg = fmt.Sprintf("%s %s: ", e.Response.Request.Method, e.Response.Request.URL)
+	}
+	msg += fmt.Sprintf("Request failed. Status code: %d", e.StatusCode)
+
+	messages := append([]string{}, e.ErrorMessages...)
+	fields := make([]string, 0, len(e.Errors))
+	for field := range e.Errors {
+		fields = append(fields, field)
+	}
+	sort.Strings(fields)
+	for _, field := range fields {
+		messages = append(messages, fmt.Sprintf("%s: %s", field, e.Errors[field]))
+	}
+	if len(messages) > 0 {
+		msg += ". " + strings.Join(messages, "; ")
+	}
+
+	return msg
+}
+
+// IsNotFound reports if err is an *ErrorResponse with status code 404 (Not Found).
+func IsNotFound(err error) bool {
+	return hasStatusCode(err, http.StatusNotFound)
+}
+
+// IsUnauthorized reports if err is an *ErrorResponse with status code 401 (Unauthorized).
+func IsUnauthorized(err error) bool {
+	return hasStatusCode(err, http.StatusUnauthorized)
+}
+
+// IsForbidden reports if err is an *ErrorResponse with status code 403 (Forbidden).
+func IsForbidden(err error) bool {
+	return hasStatusCode(err, http.StatusForbidden)
+}
+
+// IsBadRequest reports if err is an *ErrorResponse with status code 400 (Bad Request).
+// JIRA uses this status code for invalid input, e.g. a missing required field.
+func IsBadRequest(err error) bool {
+	return hasStatusCode(err, http.StatusBadRequest)
+}
+
+// IsRateLimited reports if err is an *ErrorResponse with status code 429 (Too Many Requests).
+func IsRateLimited(err error) bool {
+	return hasStatusCode(err, http.StatusTooManyRequests)
+}
+
+func hasStatusCode(err error, code int) bool {
+	var errorResponse *ErrorResponse
+	if errors.As(err, &errorResponse) {
+		return errorResponse.StatusCode == code
+	}
+	return false
 }
 
 // GetBaseURL will return you the Base URL.
@@ -197,12 +357,17 @@ func (c *Client) GetBaseURL() url.URL {
 
 // Response represents JIRA API response. It wraps http.Response returned from
 // API and provides information about paging.
+//
+// JIRA uses two styles of paging:
+// The api/2 endpoints report StartAt, MaxResults and Total.
+// The agile/1.0 endpoints report StartAt, MaxResults and IsLast (and sometimes Total).
 type Response struct {
 	*http.Response
 
 	StartAt    int
 	MaxResults int
 	Total      int
+	IsLast     bool
 }
 
 func newResponse(r *http.Response, v interface{}) *Response {
@@ -211,11 +376,33 @@ func newResponse(r *http.Response, v interface{}) *Response {
 	return resp
 }
 
-// Sets paging values if response json was parsed to searchResult type
+// Sets paging values if response json was parsed to a paged type
 // (can be extended with other types if they also need paging info)
 func (r *Response) populatePageValues(v interface{}) {
 	switch value := v.(type) {
-	case *searchResult:
+	case *SearchResult:
+		r.StartAt = value.StartAt
+		r.MaxResults = value.MaxResults
+		r.Total = value.Total
+	case *BoardsList:
+		r.StartAt = value.StartAt
+		r.MaxResults = value.MaxResults
+		r.Total = value.Total
+		r.IsLast = value.IsLast
+	case *sprintsResult:
+		r.StartAt = value.StartAt
+		r.MaxResults = value.MaxResults
+		r.IsLast = value.IsLast
+	case *IssuesInSprintResult:
+		r.StartAt = value.StartAt
+		r.MaxResults = value.MaxResults
+		r.Total = value.Total
+	case *changelogPage:
+		r.StartAt = value.StartAt
+		r.MaxResults = value.MaxResults
+		r.Total = value.Total
+		r.IsLast = value.IsLast
+	case *Worklog:
 		r.StartAt = value.StartAt
 		r.MaxResults = value.MaxResults
 		r.Total = value.Total
@@ -2,6 +2,8 @@ package jira
 
 import (
 	"bytes"
+	"context"
+	"errors"
 	"fmt"
 	"io/ioutil"
 	"net/http"
@@ -123,6 +125,105 @@ func TestCheckResponse(t *testing.T) {
 	}
 }
 
+func TestCheckResponse_ErrorResponse(t *testing.T) {
+	r := &http.Response{
+		StatusCode: http.StatusBadRequest,
+		Body:       ioutil.NopCloser(strings.NewReader(`{"errorMessages":["Issue Does Not Exist"],"errors":{"summary":"Field is required"}}`)),
+	}
+
+	err := CheckResponse(r)
+	if err == nil {
+		t.Fatal("Expected an error. Got none")
+	}
+
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Fatalf("Expected an *ErrorResponse. Got %T", err)
+	}
+	if errorResponse.StatusCode != http.StatusBadRequest {
+		t.Errorf("Expected status code %d. Got %d", http.StatusBadRequest, errorResponse.StatusCode)
+	}
+	if want := []string{"Issue Does Not Exist"}; !reflect.DeepEqual(errorResponse.ErrorMessages, want) {
+		t.Errorf("Expected error messages %v. Got %v", want, errorResponse.ErrorMessages)
+	}
+	if want := map[string]string{"summary": "Field is required"}; !reflect.DeepEqual(errorResponse.Errors, want) {
+		t.Errorf("Expected errors %v. Got %v", want, errorResponse.Errors)
+	}
+	if want := "Request failed. Status code: 400. Issue Does Not Exist; summary: Field is required"; err.Error() != want {
+		t.Errorf("Expected error string %q. Got %q", want, err.Error())
+	}
+	if !IsBadRequest(err) {
+		t.Error("Expected IsBadRequest to be true")
+	}
+
+	// The body is still readable for the caller
+	body, _ := ioutil.ReadAll(r.Body)
+	if !bytes.Equal(body, errorResponse.Body) {
+		t.Errorf("Expected body %s to be readable. Got %s", errorResponse.Body, body)
+	}
+}
+
+func TestCheckResponse_ErrorResponse_XML(t *testing.T) {
+	r := &http.Response{
+		StatusCode: http.StatusNotFound,
+		Body:       ioutil.NopCloser(strings.NewReader(`<status><status-code>404</status-code><message>null for uri: /rest/api/2/foo</message></status>`)),
+	}
+
+	err := CheckResponse(r)
+	errorResponse, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("Expected an *ErrorResponse. Got %T", err)
+	}
+	if want := []string{"null for uri: /rest/api/2/foo"}; !reflect.DeepEqual(errorResponse.ErrorMessages, want) {
+		t.Errorf("Expected error messages %v. Got %v", want, errorResponse.ErrorMessages)
+	}
+	if !IsNotFound(err) {
+		t.Error("Expected IsNotFound to be true")
+	}
+}
+
+func TestCheckResponse_ErrorResponse_HTML(t *testing.T) {
+	r := &http.Response{
+		StatusCode: http.StatusServiceUnavailable,
+		Body:       ioutil.NopCloser(strings.NewReader(`<html><head><title>Service Unavailable</title></head><body><p>Maintenance<br></body></html>`)),
+	}
+
+	err := CheckResponse(r)
+	errorResponse, ok := err.(*ErrorResponse)
+	if !ok {
+		t.Fatalf("Expected an *ErrorResponse. Got %T", err)
+	}
+	if len(errorResponse.Body) == 0 {
+		t.Error("Expected the raw body to be kept")
+	}
+	if IsNotFound(err) || IsRateLimited(err) {
+		t.Error("Expected status helpers to be false")
+	}
+}
+
+func TestErrorResponse_StatusHelpers(t *testing.T) {
+	tests := []struct {
+		code  int
+		check func(error) bool
+	}{
+		{http.StatusNotFound, IsNotFound},
+		{http.StatusUnauthorized, IsUnauthorized},
+		{http.StatusForbidden, IsForbidden},
+		{http.StatusBadRequest, IsBadRequest},
+		{http.StatusTooManyRequests, IsRateLimited},
+	}
+
+	for _, test := range tests {
+		err := fmt.Errorf("wrapped: %w", &ErrorResponse{StatusCode: test.code})
+		if !test.check(err) {
+			t.Errorf("Expected helper to match status code %d", test.code)
+		}
+		if test.check(fmt.Errorf("Status code: %d", test.code)) {
+			t.Errorf("Expected helper to ignore non *ErrorResponse errors for status code %d", test.code)
+		}
+	}
+}
+
 func TestClient_NewRequest(t *testing.T) {
 	c, err := NewClient(nil, testJIRAInstanceURL)
 	if err != nil {
@@ -154,6 +255,24 @@ func testURLParseError(t *testing.T, err error) {
 	}
 }
 
+func TestClient_NewRequestWithContext(t *testing.T) {
+	c, err := NewClient(nil, testJIRAInstanceURL)
+	if err != nil {
+		t.Errorf("An error occured. Expected nil. Got %+v.", err)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	req, err := c.NewRequestWithContext(ctx, "GET", "rest/api/2/issue/", nil)
+	if err != nil {
+		t.Fatalf("NewRequestWithContext returned unexpected error: %v", err)
+	}
+	if req.Context() != ctx {
+		t.Error("Expected the request to carry the given context")
+	}
+}
+
 func TestClient_NewRequest_BadURL(t *testing.T) {
 	c, err := NewClient(nil, testJIRAInstanceURL)
 	if err != nil {
@@ -306,6 +425,9 @@ func TestClient_Do_HTTPError(t *testing.T) {
 	if err == nil {
 		t.Error("Expected HTTP 400 error.")
 	}
+	if !IsBadRequest(err) {
+		t.Errorf("Expected an *ErrorResponse with status code 400. Got %+v", err)
+	}
 }
 
 // Test handling of an error caused by the internal http client's Do() function.
@@ -329,6 +451,25 @@ func TestClient_Do_RedirectLoop(t *testing.T) {
 	}
 }
 
+func TestClient_Do_ContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"A":"a"}`)
+	})
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+
+	req, _ := testClient.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := testClient.Do(req, nil)
+
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected context.Canceled error. Got %+v", err)
+	}
+}
+
 func TestClient_GetBaseURL_WithURL(t *testing.T) {
 	u, err := url.Parse(testJIRAInstanceURL)
 	if err != nil {
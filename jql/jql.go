@@ -0,0 +1,493 @@
+// Package jql builds JQL (JIRA Query Language) queries with correct quoting and escaping.
+//
+// Clauses are composed with Field, And, Or and Not. The result of String can be passed
+// straight to IssueService.Search:
+//
+//	q := jql.Where(jql.And(
+//		jql.Field("project").Eq("My Project"),
+//		jql.Field("status").In("Open", "Reopened"),
+//		jql.Field("assignee").Eq(jql.CurrentUser()),
+//		jql.Field("updated").Gte(jql.Relative("-7d")),
+//	)).OrderBy("priority", jql.Desc)
+//
+//	issues, _, err := client.Issue.Search(q.String(), nil)
+//
+// JQL docs: https://confluence.atlassian.com/jiracoreserver/advanced-searching-939937709.html
+package jql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+)
+
+// Operator is a JQL comparison operator.
+type Operator string
+
+// JQL operators
+const (
+	OpEquals           Operator = "="
+	OpNotEquals        Operator = "!="
+	OpGreaterThan      Operator = ">"
+	OpGreaterThanEqual Operator = ">="
+	OpLessThan         Operator = "<"
+	OpLessThanEqual    Operator = "<="
+	OpContains         Operator = "~"
+	OpNotContains      Operator = "!~"
+	OpIn               Operator = "IN"
+	OpNotIn            Operator = "NOT IN"
+	OpIs               Operator = "IS"
+	OpIsNot            Operator = "IS NOT"
+	OpWas              Operator = "WAS"
+	OpWasNot           Operator = "WAS NOT"
+	OpWasIn            Operator = "WAS IN"
+	OpWasNotIn         Operator = "WAS NOT IN"
+	OpChanged          Operator = "CHANGED"
+)
+
+// Direction is the sort direction of an ORDER BY term.
+type Direction string
+
+// Sort directions. An empty Direction uses the default of the field.
+const (
+	Asc  Direction = "ASC"
+	Desc Direction = "DESC"
+)
+
+// DateTimeFormat is the layout used to render time.Time values.
+const DateTimeFormat = "2006/01/02 15:04"
+
+// Clause is a part of the WHERE part of a JQL query.
+type Clause interface {
+	// String returns the canonical JQL representation of the clause.
+	String() string
+	clause()
+}
+
+// Operand is the right hand side of a condition.
+type Operand interface {
+	// String returns the canonical JQL representation of the operand.
+	String() string
+	operand()
+}
+
+// Condition compares a field with an operand, e.g. status = "Open".
+// History operators (WAS, CHANGED) can be refined with predicates, e.g. status CHANGED FROM "Open" AFTER -7d.
+type Condition struct {
+	Field      string
+	Operator   Operator
+	Operand    Operand
+	Predicates []Predicate
+	// Pos is the position of the field in the parsed JQL string
+	Pos Position
+}
+
+// Predicate refines a history condition, e.g. AFTER "2016/01/01".
+type Predicate struct {
+	// Name is one of AFTER, BEFORE, BY, DURING, ON, FROM and TO
+	Name string
+	// Operand is the value of the predicate. DURING uses a List with two values.
+	Operand Operand
+}
+
+// AndClause matches if all of its clauses match.
+type AndClause struct {
+	Clauses []Clause
+}
+
+// OrClause matches if any of its clauses matches.
+type OrClause struct {
+	Clauses []Clause
+}
+
+// NotClause negates a clause.
+type NotClause struct {
+	Clause Clause
+}
+
+// Value is a single value, e.g. "Open", 10 or -7d.
+// Numbers and relative dates are rendered as they are, everything else is quoted.
+type Value struct {
+	Text string
+}
+
+// List is a list of operands, e.g. ("Open", "Reopened").
+type List struct {
+	Operands []Operand
+}
+
+// FunctionCall is a JQL function, e.g. currentUser() or startOfDay(-1).
+type FunctionCall struct {
+	Name string
+	Args []string
+}
+
+// Empty is the EMPTY (or NULL) keyword.
+type Empty struct{}
+
+func (*Condition) clause()    {}
+func (*AndClause) clause()    {}
+func (*OrClause) clause()     {}
+func (*NotClause) clause()    {}
+func (Value) operand()        {}
+func (List) operand()         {}
+func (FunctionCall) operand() {}
+func (Empty) operand()        {}
+
+// String returns the canonical JQL representation of the condition.
+func (c *Condition) String() string {
+	parts := []string{quoteField(c.Field), string(c.Operator)}
+	if c.Operand != nil {
+		parts = append(parts, c.Operand.String())
+	}
+	for _, p := range c.Predicates {
+		parts = append(parts, p.String())
+	}
+	return strings.Join(parts, " ")
+}
+
+// String returns the canonical JQL representation of the predicate.
+func (p Predicate) String() string {
+	return p.Name + " " + p.Operand.String()
+}
+
+// String returns the canonical JQL representation of the clause.
+func (c *AndClause) String() string {
+	return joinClauses(c.Clauses, " AND ", func(child Clause) bool {
+		_, isOr := child.(*OrClause)
+		return isOr
+	})
+}
+
+// String returns the canonical JQL representation of the clause.
+func (c *OrClause) String() string {
+	return joinClauses(c.Clauses, " OR ", func(child Clause) bool {
+		_, isAnd := child.(*AndClause)
+		return isAnd
+	})
+}
+
+// String returns the canonical JQL representation of the clause.
+func (c *NotClause) String() string {
+	if _, ok := c.Clause.(*Condition); ok {
+		return "NOT " + c.Clause.String()
+	}
+	return "NOT (" + c.Clause.String() + ")"
+}
+
+// joinClauses joins clauses with sep and wraps the children that need parentheses.
+func joinClauses(clauses []Clause, sep string, needsParens func(Clause) bool) string {
+	parts := make([]string, 0, len(clauses))
+	for _, child := range clauses {
+		if needsParens(child) {
+			parts = append(parts, "("+child.String()+")")
+		} else {
+			parts = append(parts, child.String())
+		}
+	}
+	return strings.Join(parts, sep)
+}
+
+// String returns the canonical JQL representation of the value.
+func (v Value) String() string {
+	if bareValue.MatchString(v.Text) {
+		return v.Text
+	}
+	return Quote(v.Text)
+}
+
+// String returns the canonical JQL representation of the list.
+func (l List) String() string {
+	parts := make([]string, 0, len(l.Operands))
+	for _, o := range l.Operands {
+		parts = append(parts, o.String())
+	}
+	return "(" + strings.Join(parts, ", ") + ")"
+}
+
+// String returns the canonical JQL representation of the function call.
+func (f FunctionCall) String() string {
+	args := make([]string, 0, len(f.Args))
+	for _, arg := range f.Args {
+		args = append(args, Value{Text: arg}.String())
+	}
+	return f.Name + "(" + strings.Join(args, ", ") + ")"
+}
+
+// String returns the canonical JQL representation of EMPTY.
+func (Empty) String() string {
+	return "EMPTY"
+}
+
+var (
+	// bareValue matches values that don't need to be quoted: numbers and relative dates like -7d or 1w2d
+	bareValue = regexp.MustCompile(`^[-+]?(\d+(\.\d+)?|(\d+[ywdhm])+)$`)
+	// bareField matches field names that don't need to be quoted
+	bareField = regexp.MustCompile(`^([A-Za-z_][A-Za-z0-9_.]*|cf\[\d+\])$`)
+)
+
+// reservedWords must be quoted if used as field name.
+var reservedWords = map[string]bool{
+	"and": true, "or": true, "not": true, "empty": true, "null": true, "order": true, "by": true,
+	"asc": true, "desc": true, "in": true, "is": true, "was": true, "changed": true,
+	"after": true, "before": true, "during": true, "on": true, "from": true, "to": true,
+}
+
+// Quote returns s as quoted JQL string. Double quotes and backslashes are escaped.
+func Quote(s string) string {
+	r := strings.NewReplacer(`\`, `\\`, `"`, `\"`, "\n", `\n`, "\r", `\r`, "\t", `\t`)
+	return `"` + r.Replace(s) + `"`
+}
+
+// quoteField quotes a field name if required, e.g. "Story Points" or "order".
+func quoteField(name string) string {
+	if bareField.MatchString(name) && !reservedWords[strings.ToLower(name)] {
+		return name
+	}
+	return Quote(name)
+}
+
+// FieldRef is a reference to a field, used to build conditions.
+// Custom fields can be referenced by name ("Story Points"), id ("cf[10002]") or key ("customfield_10002").
+// JQL doesn't accept keys, so a key is written as the id of the custom field.
+type FieldRef string
+
+// Field returns a reference to the field name.
+func Field(name string) FieldRef {
+	return FieldRef(name)
+}
+
+// name returns the field name as it is written in JQL, e.g. cf[10002] for customfield_10002.
+func (f FieldRef) name() string {
+	if m := customFieldKey.FindStringSubmatch(string(f)); m != nil {
+		return "cf[" + m[1] + "]"
+	}
+	return string(f)
+}
+
+func (f FieldRef) condition(op Operator, operand Operand) *Condition {
+	return &Condition{Field: f.name(), Operator: op, Operand: operand}
+}
+
+// Eq builds the condition field = v.
+func (f FieldRef) Eq(v interface{}) *Condition { return f.condition(OpEquals, toOperand(v)) }
+
+// NotEq builds the condition field != v.
+func (f FieldRef) NotEq(v interface{}) *Condition { return f.condition(OpNotEquals, toOperand(v)) }
+
+// Gt builds the condition field > v.
+func (f FieldRef) Gt(v interface{}) *Condition { return f.condition(OpGreaterThan, toOperand(v)) }
+
+// Gte builds the condition field >= v.
+func (f FieldRef) Gte(v interface{}) *Condition { return f.condition(OpGreaterThanEqual, toOperand(v)) }
+
+// Lt builds the condition field < v.
+func (f FieldRef) Lt(v interface{}) *Condition { return f.condition(OpLessThan, toOperand(v)) }
+
+// Lte builds the condition field <= v.
+func (f FieldRef) Lte(v interface{}) *Condition { return f.condition(OpLessThanEqual, toOperand(v)) }
+
+// Contains builds the text search condition field ~ v.
+func (f FieldRef) Contains(v interface{}) *Condition { return f.condition(OpContains, toOperand(v)) }
+
+// NotContains builds the text search condition field !~ v.
+func (f FieldRef) NotContains(v interface{}) *Condition {
+	return f.condition(OpNotContains, toOperand(v))
+}
+
+// In builds the condition field IN (v1, v2, ...).
+func (f FieldRef) In(vs ...interface{}) *Condition { return f.condition(OpIn, toList(vs)) }
+
+// NotIn builds the condition field NOT IN (v1, v2, ...).
+func (f FieldRef) NotIn(vs ...interface{}) *Condition { return f.condition(OpNotIn, toList(vs)) }
+
+// IsEmpty builds the condition field IS EMPTY.
+func (f FieldRef) IsEmpty() *Condition { return f.condition(OpIs, Empty{}) }
+
+// IsNotEmpty builds the condition field IS NOT EMPTY.
+func (f FieldRef) IsNotEmpty() *Condition { return f.condition(OpIsNot, Empty{}) }
+
+// Was builds the history condition field WAS v.
+func (f FieldRef) Was(v interface{}) *Condition { return f.condition(OpWas, toOperand(v)) }
+
+// WasNot builds the history condition field WAS NOT v.
+func (f FieldRef) WasNot(v interface{}) *Condition { return f.condition(OpWasNot, toOperand(v)) }
+
+// WasIn builds the history condition field WAS IN (v1, v2, ...).
+func (f FieldRef) WasIn(vs ...interface{}) *Condition { return f.condition(OpWasIn, toList(vs)) }
+
+// WasNotIn builds the history condition field WAS NOT IN (v1, v2, ...).
+func (f FieldRef) WasNotIn(vs ...interface{}) *Condition {
+	return f.condition(OpWasNotIn, toList(vs))
+}
+
+// Changed builds the history condition field CHANGED.
+func (f FieldRef) Changed() *Condition { return f.condition(OpChanged, nil) }
+
+func (c *Condition) predicate(name string, operand Operand) *Condition {
+	c.Predicates = append(c.Predicates, Predicate{Name: name, Operand: operand})
+	return c
+}
+
+// After adds the predicate AFTER v to a history condition.
+func (c *Condition) After(v interface{}) *Condition { return c.predicate("AFTER", toOperand(v)) }
+
+// Before adds the predicate BEFORE v to a history condition.
+func (c *Condition) Before(v interface{}) *Condition { return c.predicate("BEFORE", toOperand(v)) }
+
+// On adds the predicate ON v to a history condition.
+func (c *Condition) On(v interface{}) *Condition { return c.predicate("ON", toOperand(v)) }
+
+// By adds the predicate BY v to a history condition.
+func (c *Condition) By(v interface{}) *Condition { return c.predicate("BY", toOperand(v)) }
+
+// From adds the predicate FROM v to a CHANGED condition.
+func (c *Condition) From(v interface{}) *Condition { return c.predicate("FROM", toOperand(v)) }
+
+// To adds the predicate TO v to a CHANGED condition.
+func (c *Condition) To(v interface{}) *Condition { return c.predicate("TO", toOperand(v)) }
+
+// During adds the predicate DURING (from, to) to a history condition.
+func (c *Condition) During(from, to interface{}) *Condition {
+	return c.predicate("DURING", toList([]interface{}{from, to}))
+}
+
+// And combines clauses, all of them must match.
+func And(clauses ...Clause) Clause {
+	return &AndClause{Clauses: clauses}
+}
+
+// Or combines clauses, at least one of them must match.
+func Or(clauses ...Clause) Clause {
+	return &OrClause{Clauses: clauses}
+}
+
+// Not negates clause.
+func Not(clause Clause) Clause {
+	return &NotClause{Clause: clause}
+}
+
+// toOperand converts a Go value into an operand.
+// Operands are used as they are, time.Time is formatted with DateTimeFormat
+// and everything else is converted with fmt.Sprint.
+func toOperand(v interface{}) Operand {
+	switch value := v.(type) {
+	case Operand:
+		return value
+	case string:
+		return Value{Text: value}
+	case time.Time:
+		return Value{Text: value.Format(DateTimeFormat)}
+	default:
+		return Value{Text: fmt.Sprint(value)}
+	}
+}
+
+func toList(vs []interface{}) List {
+	l := List{Operands: make([]Operand, 0, len(vs))}
+	for _, v := range vs {
+		l.Operands = append(l.Operands, toOperand(v))
+	}
+	return l
+}
+
+// Relative returns a relative date or duration like -7d, 4w or -1h, rendered without quotes.
+func Relative(duration string) Value {
+	return Value{Text: duration}
+}
+
+// Func returns a call to the JQL function name with args.
+func Func(name string, args ...string) FunctionCall {
+	return FunctionCall{Name: name, Args: args}
+}
+
+// CurrentUser returns the function currentUser().
+func CurrentUser() FunctionCall { return Func("currentUser") }
+
+// MembersOf returns the function membersOf(group).
+func MembersOf(group string) FunctionCall { return Func("membersOf", group) }
+
+// OpenSprints returns the function openSprints().
+func OpenSprints() FunctionCall { return Func("openSprints") }
+
+// ClosedSprints returns the function closedSprints().
+func ClosedSprints() FunctionCall { return Func("closedSprints") }
+
+// FutureSprints returns the function futureSprints().
+func FutureSprints() FunctionCall { return Func("futureSprints") }
+
+// Now returns the function now().
+func Now() FunctionCall { return Func("now") }
+
+// StartOfDay returns the function startOfDay() with an optional increment like -1 or +3d.
+func StartOfDay(inc ...string) FunctionCall { return Func("startOfDay", inc...) }
+
+// EndOfDay returns the function endOfDay() with an optional increment.
+func EndOfDay(inc ...string) FunctionCall { return Func("endOfDay", inc...) }
+
+// StartOfWeek returns the function startOfWeek() with an optional increment.
+func StartOfWeek(inc ...string) FunctionCall { return Func("startOfWeek", inc...) }
+
+// EndOfWeek returns the function endOfWeek() with an optional increment.
+func EndOfWeek(inc ...string) FunctionCall { return Func("endOfWeek", inc...) }
+
+// StartOfMonth returns the function startOfMonth() with an optional increment.
+func StartOfMonth(inc ...string) FunctionCall { return Func("startOfMonth", inc...) }
+
+// EndOfMonth returns the function endOfMonth() with an optional increment.
+func EndOfMonth(inc ...string) FunctionCall { return Func("endOfMonth", inc...) }
+
+// OrderByTerm is a single sort criteria of a query.
+type OrderByTerm struct {
+	Field     string
+	Direction Direction
+	// Pos is the position of the field in the parsed JQL string
+	Pos Position
+}
+
+// String returns the canonical JQL representation of the sort criteria.
+func (o OrderByTerm) String() string {
+	if o.Direction == "" {
+		return quoteField(o.Field)
+	}
+	return quoteField(o.Field) + " " + string(o.Direction)
+}
+
+// Query is a complete JQL query: an optional clause and optional sort criteria.
+type Query struct {
+	Where Clause
+	Sort  []OrderByTerm
+}
+
+// Where returns a query with the clause c.
+func Where(c Clause) *Query {
+	return &Query{Where: c}
+}
+
+// OrderBy returns a query without a clause, sorted by field.
+func OrderBy(field string, direction Direction) *Query {
+	return (&Query{}).OrderBy(field, direction)
+}
+
+// OrderBy adds a sort criteria to the query.
+func (q *Query) OrderBy(field string, direction Direction) *Query {
+	q.Sort = append(q.Sort, OrderByTerm{Field: field, Direction: direction})
+	return q
+}
+
+// String returns the canonical JQL representation of the query.
+func (q *Query) String() string {
+	var parts []string
+	if q.Where != nil {
+		parts = append(parts, q.Where.String())
+	}
+	if len(q.Sort) > 0 {
+		terms := make([]string, 0, len(q.Sort))
+		for _, o := range q.Sort {
+			terms = append(terms, o.String())
+		}
+		parts = append(parts, "ORDER BY "+strings.Join(terms, ", "))
+	}
+	return strings.Join(parts, " ")
+}
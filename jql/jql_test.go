@@ -0,0 +1,119 @@
+package jql
+
+import (
+	"fmt"
+	"testing"
+	"time"
+)
+
+func TestQuery_String(t *testing.T) {
+	tests := []struct {
+		query fmt.Stringer
+		want  string
+	}{
+		{
+			Field("status").In("Open", "Reopened"),
+			`status IN ("Open", "Reopened")`,
+		},
+		{
+			Field("project").Eq("My Project"),
+			`project = "My Project"`,
+		},
+		{
+			Field("summary").Contains(`say "hello" \ world`),
+			`summary ~ "say \"hello\" \\ world"`,
+		},
+		{
+			Field("Story Points").Gte(5),
+			`"Story Points" >= 5`,
+		},
+		{
+			Field("cf[10002]").Lt(2.5),
+			`cf[10002] < 2.5`,
+		},
+		{
+			Field("customfield_10002").Lt(2.5),
+			`cf[10002] < 2.5`,
+		},
+		{
+			Field("order").Eq("x"),
+			`"order" = "x"`,
+		},
+		{
+			Field("assignee").Eq(CurrentUser()),
+			`assignee = currentUser()`,
+		},
+		{
+			Field("assignee").In(MembersOf("jira users")),
+			`assignee IN (membersOf("jira users"))`,
+		},
+		{
+			Field("sprint").In(OpenSprints(), FutureSprints()),
+			`sprint IN (openSprints(), futureSprints())`,
+		},
+		{
+			Field("created").Gte(StartOfDay("-1")),
+			`created >= startOfDay(-1)`,
+		},
+		{
+			Field("updated").Gte(Relative("-7d")),
+			`updated >= -7d`,
+		},
+		{
+			Field("created").Lt(time.Date(2016, 3, 16, 4, 22, 0, 0, time.UTC)),
+			`created < "2016/03/16 04:22"`,
+		},
+		{
+			Field("fixVersion").IsEmpty(),
+			`fixVersion IS EMPTY`,
+		},
+		{
+			Field("resolution").IsNotEmpty(),
+			`resolution IS NOT EMPTY`,
+		},
+		{
+			Field("status").Changed().From("Open").To("In Progress").After(Relative("-2w")),
+			`status CHANGED FROM "Open" TO "In Progress" AFTER -2w`,
+		},
+		{
+			Field("status").WasIn("Open", "Reopened").During("2016/01/01", "2016/02/01").By("fred"),
+			`status WAS IN ("Open", "Reopened") DURING ("2016/01/01", "2016/02/01") BY "fred"`,
+		},
+		{
+			And(Field("project").Eq("EX"), Or(Field("priority").Eq("High"), Field("labels").Eq("urgent"))),
+			`project = "EX" AND (priority = "High" OR labels = "urgent")`,
+		},
+		{
+			Or(And(Field("a").Eq(1), Field("b").Eq(2)), Field("c").NotEq(3)),
+			`(a = 1 AND b = 2) OR c != 3`,
+		},
+		{
+			Not(Field("status").Eq("Done")),
+			`NOT status = "Done"`,
+		},
+		{
+			Not(Or(Field("a").Eq(1), Field("b").Eq(2))),
+			`NOT (a = 1 OR b = 2)`,
+		},
+		{
+			Where(Field("project").Eq("EX")).OrderBy("priority", Desc).OrderBy("created", ""),
+			`project = "EX" ORDER BY priority DESC, created`,
+		},
+		{
+			OrderBy("Rank", Asc),
+			`ORDER BY Rank ASC`,
+		},
+	}
+
+	for _, test := range tests {
+		if got := test.query.String(); got != test.want {
+			t.Errorf("Expected %s. Got %s", test.want, got)
+		}
+	}
+}
+
+func TestQuote(t *testing.T) {
+	if got, want := Quote("a\"b\\c\nd"), `"a\"b\\c\nd"`; got != want {
+		t.Errorf("Expected %s. Got %s", want, got)
+	}
+}
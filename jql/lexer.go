@@ -0,0 +1,203 @@
+package jql
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Position is a location in a JQL string. Line and Column start at 1.
+type Position struct {
+	Offset int
+	Line   int
+	Column int
+}
+
+// String returns the position as line:column.
+func (p Position) String() string {
+	return fmt.Sprintf("%d:%d", p.Line, p.Column)
+}
+
+// tokenType is the type of a lexical token.
+type tokenType int
+
+const (
+	tokenEOF tokenType = iota
+	tokenWord
+	tokenString
+	tokenOperator
+	tokenLParen
+	tokenRParen
+	tokenComma
+	tokenAnd // && (the keyword AND is a word)
+	tokenOr  // || (the keyword OR is a word)
+	tokenNot // !  (the keyword NOT is a word)
+)
+
+// token is a lexical token of a JQL string.
+type token struct {
+	typ tokenType
+	// text is the unquoted value for strings and the literal text for everything else
+	text string
+	pos  Position
+}
+
+// is reports if t is the keyword kw (case-insensitive).
+func (t token) is(kw string) bool {
+	return t.typ == tokenWord && strings.EqualFold(t.text, kw)
+}
+
+func (t token) String() string {
+	switch t.typ {
+	case tokenEOF:
+		return "end of query"
+	case tokenString:
+		return Quote(t.text)
+	default:
+		return fmt.Sprintf("%q", t.text)
+	}
+}
+
+// SyntaxError reports an invalid JQL string.
+type SyntaxError struct {
+	Pos Position
+	Msg string
+}
+
+func (e *SyntaxError) Error() string {
+	return fmt.Sprintf("jql: %s: %s", e.Pos, e.Msg)
+}
+
+// lexer splits a JQL string into tokens.
+type lexer struct {
+	input string
+	pos   Position
+}
+
+func newLexer(input string) *lexer {
+	return &lexer{input: input, pos: Position{Line: 1, Column: 1}}
+}
+
+// special characters that terminate an unquoted word
+const specialChars = `()=!<>~,"'&|`
+
+func (l *lexer) peekRune() rune {
+	if l.pos.Offset >= len(l.input) {
+		return -1
+	}
+	r, _ := utf8.DecodeRuneInString(l.input[l.pos.Offset:])
+	return r
+}
+
+func (l *lexer) nextRune() rune {
+	if l.pos.Offset >= len(l.input) {
+		return -1
+	}
+	r, size := utf8.DecodeRuneInString(l.input[l.pos.Offset:])
+	l.pos.Offset += size
+	if r == '\n' {
+		l.pos.Line++
+		l.pos.Column = 1
+	} else {
+		l.pos.Column++
+	}
+	return r
+}
+
+// tokens returns all tokens of the input, terminated by tokenEOF.
+func (l *lexer) tokens() ([]token, error) {
+	var tokens []token
+	for {
+		t, err := l.next()
+		if err != nil {
+			return nil, err
+		}
+		tokens = append(tokens, t)
+		if t.typ == tokenEOF {
+			return tokens, nil
+		}
+	}
+}
+
+func (l *lexer) next() (token, error) {
+	for unicode.IsSpace(l.peekRune()) {
+		l.nextRune()
+	}
+
+	start := l.pos
+	r := l.nextRune()
+	switch {
+	case r == -1:
+		return token{typ: tokenEOF, pos: start}, nil
+	case r == '(':
+		return token{typ: tokenLParen, text: "(", pos: start}, nil
+	case r == ')':
+		return token{typ: tokenRParen, text: ")", pos: start}, nil
+	case r == ',':
+		return token{typ: tokenComma, text: ",", pos: start}, nil
+	case r == '=' || r == '~':
+		return token{typ: tokenOperator, text: string(r), pos: start}, nil
+	case r == '!' || r == '<' || r == '>':
+		if next := l.peekRune(); next == '=' || (r == '!' && next == '~') {
+			l.nextRune()
+			return token{typ: tokenOperator, text: string(r) + string(next), pos: start}, nil
+		}
+		if r == '!' {
+			return token{typ: tokenNot, text: "!", pos: start}, nil
+		}
+		return token{typ: tokenOperator, text: string(r), pos: start}, nil
+	case r == '&' || r == '|':
+		if l.peekRune() != r {
+			return token{}, &SyntaxError{Pos: start, Msg: fmt.Sprintf("unexpected %q, did you mean %q", r, string(r)+string(r))}
+		}
+		l.nextRune()
+		if r == '&' {
+			return token{typ: tokenAnd, text: "&&", pos: start}, nil
+		}
+		return token{typ: tokenOr, text: "||", pos: start}, nil
+	case r == '"' || r == '\'':
+		return l.quoted(r, start)
+	default:
+		var b strings.Builder
+		b.WriteRune(r)
+		for {
+			next := l.peekRune()
+			if next == -1 || unicode.IsSpace(next) || strings.ContainsRune(specialChars, next) {
+				break
+			}
+			b.WriteRune(l.nextRune())
+		}
+		return token{typ: tokenWord, text: b.String(), pos: start}, nil
+	}
+}
+
+// quoted reads a string enclosed in quote. The opening quote has already been read.
+func (l *lexer) quoted(quote rune, start Position) (token, error) {
+	var b strings.Builder
+	for {
+		r := l.nextRune()
+		switch r {
+		case -1:
+			return token{}, &SyntaxError{Pos: start, Msg: "unterminated string"}
+		case quote:
+			return token{typ: tokenString, text: b.String(), pos: start}, nil
+		case '\\':
+			escaped := l.nextRune()
+			switch escaped {
+			case 'n':
+				b.WriteRune('\n')
+			case 'r':
+				b.WriteRune('\r')
+			case 't':
+				b.WriteRune('\t')
+			case -1:
+				return token{}, &SyntaxError{Pos: start, Msg: "unterminated string"}
+			default:
+				b.WriteRune(escaped)
+			}
+		default:
+			b.WriteRune(r)
+		}
+	}
+}
@@ -0,0 +1,374 @@
+package jql
+
+import (
+	"fmt"
+	"strings"
+)
+
+// Parse parses a JQL string into a Query.
+// Syntax errors are reported as *SyntaxError including the position of the problem.
+// The String method of the returned Query renders the canonical form of s.
+func Parse(s string) (*Query, error) {
+	tokens, err := newLexer(s).tokens()
+	if err != nil {
+		return nil, err
+	}
+
+	p := &parser{tokens: tokens}
+	q := &Query{}
+	if !p.peek().is("order") && p.peek().typ != tokenEOF {
+		if q.Where, err = p.parseOr(); err != nil {
+			return nil, err
+		}
+	}
+	if p.peek().is("order") {
+		if q.Sort, err = p.parseOrderBy(); err != nil {
+			return nil, err
+		}
+	}
+	if t := p.peek(); t.typ != tokenEOF {
+		return nil, p.errorf(t, "unexpected %s", t)
+	}
+	return q, nil
+}
+
+// MustParse is like Parse but panics if s can not be parsed.
+// It simplifies the initialization of global variables holding queries.
+func MustParse(s string) *Query {
+	q, err := Parse(s)
+	if err != nil {
+		panic(err)
+	}
+	return q
+}
+
+// parser is a recursive descent parser for JQL:
+//
+//	query     = [ or ] [ "ORDER" "BY" sortTerm { "," sortTerm } ]
+//	or        = and { ( "OR" | "||" ) and }
+//	and       = not { ( "AND" | "&&" ) not }
+//	not       = ( "NOT" | "!" ) not | "(" or ")" | condition
+//	condition = field operator [ operand ] { predicate }
+type parser struct {
+	tokens []token
+	index  int
+}
+
+func (p *parser) peek() token {
+	return p.tokens[p.index]
+}
+
+func (p *parser) next() token {
+	t := p.tokens[p.index]
+	if t.typ != tokenEOF {
+		p.index++
+	}
+	return t
+}
+
+func (p *parser) errorf(t token, format string, args ...interface{}) error {
+	return &SyntaxError{Pos: t.pos, Msg: fmt.Sprintf(format, args...)}
+}
+
+func (p *parser) parseOr() (Clause, error) {
+	first, err := p.parseAnd()
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []Clause{first}
+	for p.peek().is("or") || p.peek().typ == tokenOr {
+		p.next()
+		c, err := p.parseAnd()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, c)
+	}
+	if len(clauses) == 1 {
+		return first, nil
+	}
+
+	or := &OrClause{}
+	for _, c := range clauses {
+		// (a OR b) OR c is the same as a OR b OR c
+		if nested, ok := c.(*OrClause); ok {
+			or.Clauses = append(or.Clauses, nested.Clauses...)
+		} else {
+			or.Clauses = append(or.Clauses, c)
+		}
+	}
+	return or, nil
+}
+
+func (p *parser) parseAnd() (Clause, error) {
+	first, err := p.parseNot()
+	if err != nil {
+		return nil, err
+	}
+
+	clauses := []Clause{first}
+	for p.peek().is("and") || p.peek().typ == tokenAnd {
+		p.next()
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		clauses = append(clauses, c)
+	}
+	if len(clauses) == 1 {
+		return first, nil
+	}
+
+	and := &AndClause{}
+	for _, c := range clauses {
+		// (a AND b) AND c is the same as a AND b AND c
+		if nested, ok := c.(*AndClause); ok {
+			and.Clauses = append(and.Clauses, nested.Clauses...)
+		} else {
+			and.Clauses = append(and.Clauses, c)
+		}
+	}
+	return and, nil
+}
+
+func (p *parser) parseNot() (Clause, error) {
+	t := p.peek()
+	switch {
+	case t.is("not") || t.typ == tokenNot:
+		p.next()
+		c, err := p.parseNot()
+		if err != nil {
+			return nil, err
+		}
+		return &NotClause{Clause: c}, nil
+	case t.typ == tokenLParen:
+		p.next()
+		c, err := p.parseOr()
+		if err != nil {
+			return nil, err
+		}
+		if closing := p.next(); closing.typ != tokenRParen {
+			return nil, p.errorf(closing, "expected \")\", found %s", closing)
+		}
+		return c, nil
+	default:
+		return p.parseCondition()
+	}
+}
+
+// parseField reads a field name. Reserved words must be quoted to be used as field name.
+func (p *parser) parseField() (token, error) {
+	t := p.next()
+	if t.typ == tokenString || (t.typ == tokenWord && !reservedWords[strings.ToLower(t.text)]) {
+		return t, nil
+	}
+	return t, p.errorf(t, "expected field, found %s", t)
+}
+
+func (p *parser) parseCondition() (Clause, error) {
+	field, err := p.parseField()
+	if err != nil {
+		return nil, err
+	}
+	c := &Condition{Field: field.text, Pos: field.pos}
+
+	opToken := p.peek()
+	if c.Operator, err = p.parseOperator(); err != nil {
+		return nil, err
+	}
+
+	switch c.Operator {
+	case OpChanged:
+		// CHANGED has no operand
+	case OpIn, OpNotIn, OpWasIn, OpWasNotIn:
+		if c.Operand, err = p.parseOperand(); err != nil {
+			return nil, err
+		}
+		switch c.Operand.(type) {
+		case List, FunctionCall:
+		default:
+			return nil, p.errorf(opToken, "operator %s requires a list or function", c.Operator)
+		}
+	default:
+		if c.Operand, err = p.parseOperand(); err != nil {
+			return nil, err
+		}
+		if _, isList := c.Operand.(List); isList {
+			return nil, p.errorf(opToken, "operator %s does not accept a list", c.Operator)
+		}
+	}
+
+	if c.Operator == OpIs || c.Operator == OpIsNot {
+		if _, isEmpty := c.Operand.(Empty); !isEmpty {
+			return nil, p.errorf(opToken, "operator %s requires EMPTY or NULL", c.Operator)
+		}
+	}
+
+	history := c.Operator == OpChanged || strings.HasPrefix(string(c.Operator), "WAS")
+	for isPredicate(p.peek()) {
+		t := p.next()
+		if !history {
+			return nil, p.errorf(t, "predicate %s is only allowed after WAS or CHANGED", strings.ToUpper(t.text))
+		}
+		predicate := Predicate{Name: strings.ToUpper(t.text)}
+		if predicate.Operand, err = p.parseOperand(); err != nil {
+			return nil, err
+		}
+		if l, isList := predicate.Operand.(List); predicate.Name == "DURING" && (!isList || len(l.Operands) != 2) {
+			return nil, p.errorf(t, "predicate DURING requires two values")
+		}
+		c.Predicates = append(c.Predicates, predicate)
+	}
+
+	return c, nil
+}
+
+func isPredicate(t token) bool {
+	for _, name := range []string{"after", "before", "during", "on", "by", "from", "to"} {
+		if t.is(name) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *parser) parseOperator() (Operator, error) {
+	t := p.next()
+	if t.typ == tokenOperator {
+		return Operator(t.text), nil
+	}
+
+	switch {
+	case t.is("in"):
+		return OpIn, nil
+	case t.is("not"):
+		if in := p.next(); !in.is("in") {
+			return "", p.errorf(in, "expected IN after NOT, found %s", in)
+		}
+		return OpNotIn, nil
+	case t.is("is"):
+		if p.peek().is("not") {
+			p.next()
+			return OpIsNot, nil
+		}
+		return OpIs, nil
+	case t.is("was"):
+		not := p.peek().is("not")
+		if not {
+			p.next()
+		}
+		in := p.peek().is("in")
+		if in {
+			p.next()
+		}
+		switch {
+		case not && in:
+			return OpWasNotIn, nil
+		case not:
+			return OpWasNot, nil
+		case in:
+			return OpWasIn, nil
+		}
+		return OpWas, nil
+	case t.is("changed"):
+		return OpChanged, nil
+	}
+	return "", p.errorf(t, "expected operator, found %s", t)
+}
+
+// parseOperand reads a value, a function call or a list of those.
+func (p *parser) parseOperand() (Operand, error) {
+	t := p.next()
+	switch t.typ {
+	case tokenString:
+		return Value{Text: t.text}, nil
+	case tokenLParen:
+		l := List{}
+		for {
+			o, err := p.parseOperand()
+			if err != nil {
+				return nil, err
+			}
+			if _, nested := o.(List); nested {
+				return nil, p.errorf(t, "lists can not be nested")
+			}
+			l.Operands = append(l.Operands, o)
+
+			sep := p.next()
+			if sep.typ == tokenRParen {
+				return l, nil
+			}
+			if sep.typ != tokenComma {
+				return nil, p.errorf(sep, "expected \",\" or \")\", found %s", sep)
+			}
+		}
+	case tokenWord:
+		if t.is("empty") || t.is("null") {
+			return Empty{}, nil
+		}
+		if reservedWords[strings.ToLower(t.text)] {
+			return nil, p.errorf(t, "expected value, found %s", t)
+		}
+		if p.peek().typ == tokenLParen {
+			return p.parseFunctionArgs(t)
+		}
+		return Value{Text: t.text}, nil
+	}
+	return nil, p.errorf(t, "expected value, found %s", t)
+}
+
+// parseFunctionArgs reads the arguments of the function name.
+func (p *parser) parseFunctionArgs(name token) (Operand, error) {
+	p.next() // (
+	f := FunctionCall{Name: name.text}
+	if p.peek().typ == tokenRParen {
+		p.next()
+		return f, nil
+	}
+	for {
+		arg := p.next()
+		if arg.typ != tokenWord && arg.typ != tokenString {
+			return nil, p.errorf(arg, "expected function argument, found %s", arg)
+		}
+		f.Args = append(f.Args, arg.text)
+
+		sep := p.next()
+		if sep.typ == tokenRParen {
+			return f, nil
+		}
+		if sep.typ != tokenComma {
+			return nil, p.errorf(sep, "expected \",\" or \")\", found %s", sep)
+		}
+	}
+}
+
+func (p *parser) parseOrderBy() ([]OrderByTerm, error) {
+	p.next() // ORDER
+	if by := p.next(); !by.is("by") {
+		return nil, p.errorf(by, "expected BY after ORDER, found %s", by)
+	}
+
+	var terms []OrderByTerm
+	for {
+		field, err := p.parseField()
+		if err != nil {
+			return nil, err
+		}
+		term := OrderByTerm{Field: field.text, Pos: field.pos}
+		switch {
+		case p.peek().is("asc"):
+			p.next()
+			term.Direction = Asc
+		case p.peek().is("desc"):
+			p.next()
+			term.Direction = Desc
+		}
+		terms = append(terms, term)
+
+		if p.peek().typ != tokenComma {
+			return terms, nil
+		}
+		p.next()
+	}
+}
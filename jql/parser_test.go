@@ -0,0 +1,101 @@
+package jql
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestParse(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`project = EX`, `project = "EX"`},
+		{`project = 'My Project' and status in (Open, "In Progress")`, `project = "My Project" AND status IN ("Open", "In Progress")`},
+		{`"Story Points" >= 5 ORDER BY created desc, key`, `"Story Points" >= 5 ORDER BY created DESC, key`},
+		{`cf[10002] < 2.5`, `cf[10002] < 2.5`},
+		{`a = 1 && b = 2 || !c = 3`, `(a = 1 AND b = 2) OR NOT c = 3`},
+		{`a = 1 AND (b = 2 AND c = 3)`, `a = 1 AND b = 2 AND c = 3`},
+		{`(a = 1 OR b = 2) OR c = 3`, `a = 1 OR b = 2 OR c = 3`},
+		{`not (a = 1 or b = 2)`, `NOT (a = 1 OR b = 2)`},
+		{`fixVersion is empty and resolution IS NOT null`, `fixVersion IS EMPTY AND resolution IS NOT EMPTY`},
+		{`status not in (Done)`, `status NOT IN ("Done")`},
+		{`summary ~ "say \"hello\""`, `summary ~ "say \"hello\""`},
+		{`summary !~ foo`, `summary !~ "foo"`},
+		{`assignee = currentUser()`, `assignee = currentUser()`},
+		{`assignee in membersOf("jira users")`, `assignee IN membersOf("jira users")`},
+		{`created >= startOfDay(-1) AND updated > -7d`, `created >= startOfDay(-1) AND updated > -7d`},
+		{`status was not in (Open, Reopened) during ("2016/01/01", "2016/02/01") by fred`, `status WAS NOT IN ("Open", "Reopened") DURING ("2016/01/01", "2016/02/01") BY "fred"`},
+		{`status changed from Open to "In Progress" after -2w`, `status CHANGED FROM "Open" TO "In Progress" AFTER -2w`},
+		{`ORDER BY Rank ASC`, `ORDER BY Rank ASC`},
+		{``, ``},
+	}
+
+	for _, test := range tests {
+		q, err := Parse(test.in)
+		if err != nil {
+			t.Errorf("Parse(%q) returned error: %v", test.in, err)
+			continue
+		}
+		if got := q.String(); got != test.want {
+			t.Errorf("Parse(%q): Expected %s. Got %s", test.in, test.want, got)
+		}
+	}
+}
+
+func TestParse_Position(t *testing.T) {
+	q, err := Parse("project = EX\n  AND \"Story Points\" > 3 ORDER BY rank")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	and := q.Where.(*AndClause)
+	if got, want := and.Clauses[1].(*Condition).Pos, (Position{Offset: 19, Line: 2, Column: 7}); got != want {
+		t.Errorf("Expected %v. Got %v", want, got)
+	}
+	if got, want := q.Sort[0].Pos.Column, 35; got != want {
+		t.Errorf("Expected column %d. Got %d", want, got)
+	}
+}
+
+func TestParse_SyntaxError(t *testing.T) {
+	tests := []struct {
+		in   string
+		want string
+	}{
+		{`project =`, `jql: 1:10: expected value, found end of query`},
+		{`project = "EX`, `jql: 1:11: unterminated string`},
+		{`project EX`, `jql: 1:9: expected operator, found "EX"`},
+		{`(a = 1`, `jql: 1:7: expected ")", found end of query`},
+		{`a = 1 b = 2`, `jql: 1:7: unexpected "b"`},
+		{`a = 1 & b = 2`, `jql: 1:7: unexpected '&', did you mean "&&"`},
+		{`status in Open`, `jql: 1:8: operator IN requires a list or function`},
+		{`status = (Open, Done)`, `jql: 1:8: operator = does not accept a list`},
+		{`status is Open`, `jql: 1:8: operator IS requires EMPTY or NULL`},
+		{`status = Open after -1d`, `jql: 1:15: predicate AFTER is only allowed after WAS or CHANGED`},
+		{`status was Open during -1d`, `jql: 1:17: predicate DURING requires two values`},
+		{`and = 1`, `jql: 1:1: expected field, found "and"`},
+		{`a = 1 order rank`, `jql: 1:13: expected BY after ORDER, found "rank"`},
+	}
+
+	for _, test := range tests {
+		_, err := Parse(test.in)
+		var syntaxErr *SyntaxError
+		if !errors.As(err, &syntaxErr) {
+			t.Errorf("Parse(%q): Expected *SyntaxError. Got %v", test.in, err)
+			continue
+		}
+		if got := err.Error(); got != test.want {
+			t.Errorf("Parse(%q): Expected %s. Got %s", test.in, test.want, got)
+		}
+	}
+}
+
+func TestMustParse_Panics(t *testing.T) {
+	defer func() {
+		if recover() == nil {
+			t.Error("Expected MustParse to panic")
+		}
+	}()
+	MustParse("project =")
+}
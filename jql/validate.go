@@ -0,0 +1,109 @@
+package jql
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+)
+
+// builtinFields are available in every JQL query, even though most of them are
+// not part of the create metadata of an issue type.
+var builtinFields = []string{
+	"affectedVersion", "assignee", "attachments", "category", "comment", "component", "created",
+	"createdDate", "creator", "description", "due", "duedate", "environment", "filter", "fixVersion",
+	"id", "issue", "issuekey", "issueLink", "issueLinkType", "issuetype", "key", "labels", "lastViewed",
+	"level", "originalEstimate", "parent", "priority", "project", "Rank", "remainingEstimate",
+	"reporter", "request", "resolution", "resolutiondate", "resolved", "sprint", "status",
+	"statusCategory", "summary", "text", "timeestimate", "timeoriginalestimate", "timespent",
+	"type", "updated", "updatedDate", "voter", "votes", "watcher", "watchers", "worklogAuthor",
+	"worklogComment", "worklogDate", "workratio",
+}
+
+// customFieldKey matches the key of a custom field, e.g. customfield_10002
+var customFieldKey = regexp.MustCompile(`^customfield_(\d+)$`)
+
+// FieldError reports a field that is not known to the JIRA instance.
+type FieldError struct {
+	Pos   Position
+	Field string
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("jql: %s: unknown field %s", e.Pos, Quote(e.Field))
+}
+
+// ValidationError lists all problems found by Validate.
+type ValidationError []*FieldError
+
+func (e ValidationError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, fieldErr := range e {
+		msgs = append(msgs, fieldErr.Error())
+	}
+	return strings.Join(msgs, "\n")
+}
+
+// Validate checks that all fields referenced by q are known.
+// fields maps field names to field keys as returned by MetaIssueType.GetAllFields,
+// e.g. "Story Points" -> "customfield_10002".
+// A reference matches a name, a key or the JQL alias of a custom field key (cf[10002]), ignoring case.
+// Fields that are available in every query (like key, created or sprint) are always accepted.
+// All unknown fields are reported at once as ValidationError.
+func Validate(q *Query, fields map[string]string) error {
+	known := make(map[string]bool, len(builtinFields)+2*len(fields))
+	for _, name := range builtinFields {
+		known[strings.ToLower(name)] = true
+	}
+	for name, key := range fields {
+		known[strings.ToLower(name)] = true
+		known[strings.ToLower(key)] = true
+		if m := customFieldKey.FindStringSubmatch(key); m != nil {
+			known["cf["+m[1]+"]"] = true
+		}
+	}
+
+	var errs ValidationError
+	check := func(field string, pos Position) {
+		if !known[strings.ToLower(field)] {
+			errs = append(errs, &FieldError{Pos: pos, Field: field})
+		}
+	}
+
+	var walk func(c Clause)
+	walk = func(c Clause) {
+		switch clause := c.(type) {
+		case *Condition:
+			check(clause.Field, clause.Pos)
+		case *AndClause:
+			for _, child := range clause.Clauses {
+				walk(child)
+			}
+		case *OrClause:
+			for _, child := range clause.Clauses {
+				walk(child)
+			}
+		case *NotClause:
+			walk(clause.Clause)
+		}
+	}
+	if q.Where != nil {
+		walk(q.Where)
+	}
+	for _, term := range q.Sort {
+		check(term.Field, term.Pos)
+	}
+
+	if len(errs) > 0 {
+		return errs
+	}
+	return nil
+}
+
+// Lint parses s and validates the referenced fields against fields, see Validate.
+func Lint(s string, fields map[string]string) error {
+	q, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	return Validate(q, fields)
+}
@@ -0,0 +1,47 @@
+package jql
+
+import (
+	"errors"
+	"testing"
+)
+
+var testFields = map[string]string{
+	"Summary":      "summary",
+	"Story Points": "customfield_10002",
+	"Team":         "customfield_10100",
+}
+
+func TestValidate(t *testing.T) {
+	q := MustParse(`project = EX AND "story points" > 3 AND cf[10100] = Core AND customfield_10002 IS NOT EMPTY ORDER BY Rank`)
+	if err := Validate(q, testFields); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestValidate_UnknownFields(t *testing.T) {
+	q := MustParse("project = EX AND (Storypoints > 3 OR NOT cf[99] = 1) ORDER BY teem")
+	err := Validate(q, testFields)
+
+	var validationErr ValidationError
+	if !errors.As(err, &validationErr) {
+		t.Fatalf("Expected ValidationError. Got %v", err)
+	}
+	if len(validationErr) != 3 {
+		t.Fatalf("Expected 3 errors. Got %d", len(validationErr))
+	}
+	want := "jql: 1:19: unknown field \"Storypoints\"\njql: 1:42: unknown field \"cf[99]\"\njql: 1:63: unknown field \"teem\""
+	if got := err.Error(); got != want {
+		t.Errorf("Expected %s. Got %s", want, got)
+	}
+}
+
+func TestLint(t *testing.T) {
+	if err := Lint("Team = Core", testFields); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	var syntaxErr *SyntaxError
+	if err := Lint("Team =", testFields); !errors.As(err, &syntaxErr) {
+		t.Errorf("Expected *SyntaxError. Got %v", err)
+	}
+}
@@ -0,0 +1,288 @@
+package jira
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"sync"
+	"time"
+)
+
+// defaultMetaCacheTTL is the time metadata is cached if MetaCacheOptions.TTL is not set
+const defaultMetaCacheTTL = time.Hour
+
+// MetaCacheOptions specifies the optional parameters to NewMetaCache
+type MetaCacheOptions struct {
+	// TTL is the time after which cached metadata is fetched again. Default: 1 hour.
+	// A negative TTL keeps metadata until it is invalidated.
+	TTL time.Duration
+
+	// Path is the file the cache is persisted to. The cache is loaded from it by NewMetaCache
+	// and written to it whenever metadata was fetched or invalidated.
+	// By default, the cache is kept in memory only.
+	// If the file can not be written, the metadata is still returned, together with the error.
+	Path string
+}
+
+// MetaCache caches the create metadata of issue types and the edit metadata of issues,
+// so they don't need to be fetched from JIRA for every issue that is created or updated.
+// Create metadata is cached per project and issue type, edit metadata per issue.
+//
+// A MetaCache is safe for concurrent use. The returned metadata is shared by all callers and must not be modified.
+// If the same metadata is requested concurrently while it is not cached, it may be fetched more than once.
+//
+//	cache, err := jira.NewMetaCache(client, &jira.MetaCacheOptions{TTL: 24 * time.Hour})
+//	project, issueType, err := cache.GetCreateMeta("ENG", "Bug")
+//	issue, err := jira.InitIssueWithMetaAndFields(project, issueType, fields)
+type MetaCache struct {
+	client *Client
+	ttl    time.Duration
+	path   string
+	now    func() time.Time
+
+	mu         sync.Mutex
+	createMeta map[string]*createMetaEntry
+	editMeta   map[string]*editMetaEntry
+}
+
+// createMetaEntry is the cached create metadata of a single issue type.
+// Project contains no issue types, to not store them twice.
+type createMetaEntry struct {
+	Project   *MetaProject   `json:"project"`
+	IssueType *MetaIssueType `json:"issueType"`
+	Fetched   time.Time      `json:"fetched"`
+}
+
+// editMetaEntry is the cached edit metadata of a single issue
+type editMetaEntry struct {
+	EditMeta *EditMetaInfo `json:"editMeta"`
+	Fetched  time.Time     `json:"fetched"`
+}
+
+// metaCacheFile is the format of a persisted MetaCache
+type metaCacheFile struct {
+	CreateMeta map[string]*createMetaEntry `json:"createMeta,omitempty"`
+	EditMeta   map[string]*editMetaEntry   `json:"editMeta,omitempty"`
+}
+
+// NewMetaCache returns a MetaCache fetching metadata with client. options can be nil.
+// If options.Path is given and the file exists, the cache is loaded from it. Expired entries are dropped.
+func NewMetaCache(client *Client, options *MetaCacheOptions) (*MetaCache, error) {
+	opt := MetaCacheOptions{}
+	if options != nil {
+		opt = *options
+	}
+	if opt.TTL == 0 {
+		opt.TTL = defaultMetaCacheTTL
+	}
+
+	c := &MetaCache{
+		client:     client,
+		ttl:        opt.TTL,
+		path:       opt.Path,
+		now:        time.Now,
+		createMeta: map[string]*createMetaEntry{},
+		editMeta:   map[string]*editMetaEntry{},
+	}
+	if err := c.load(); err != nil {
+		return nil, err
+	}
+	return c, nil
+}
+
+// GetCreateMetaWithContext returns the create metadata of the issue type issueTypeName in the project projectKey.
+// The project is returned without its issue types. Only the issue type is fetched from JIRA if it is not cached.
+// Project keys and issue type names are compared case insensitive.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getCreateIssueMeta
+func (c *MetaCache) GetCreateMetaWithContext(ctx context.Context, projectKey, issueTypeName string) (*MetaProject, *MetaIssueType, error) {
+	key := createMetaKey(projectKey, issueTypeName)
+
+	c.mu.Lock()
+	entry, ok := c.createMeta[key]
+	if ok && c.expired(entry.Fetched) {
+		delete(c.createMeta, key)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		return entry.Project, entry.IssueType, nil
+	}
+
+	meta, _, err := c.client.Issue.GetCreateMetaWithOptionsWithContext(ctx, &GetCreateMetaOptions{
+		ProjectKeys:    []string{projectKey},
+		IssueTypeNames: []string{issueTypeName},
+		Expand:         "projects.issuetypes.fields",
+	})
+	if err != nil {
+		return nil, nil, err
+	}
+	project := meta.GetProjectWithKey(projectKey)
+	if project == nil {
+		return nil, nil, fmt.Errorf("project %s not found in create metadata", projectKey)
+	}
+	issueType := project.GetIssueTypeWithName(issueTypeName)
+	if issueType == nil {
+		return nil, nil, fmt.Errorf("issue type %s not found in create metadata of project %s", issueTypeName, projectKey)
+	}
+	projectOnly := *project
+	projectOnly.IssueTypes = nil
+	entry = &createMetaEntry{Project: &projectOnly, IssueType: issueType, Fetched: c.now()}
+
+	c.mu.Lock()
+	c.createMeta[key] = entry
+	err = c.save()
+	c.mu.Unlock()
+
+	return entry.Project, entry.IssueType, err
+}
+
+// GetCreateMeta wraps GetCreateMetaWithContext using the background context.
+func (c *MetaCache) GetCreateMeta(projectKey, issueTypeName string) (*MetaProject, *MetaIssueType, error) {
+	return c.GetCreateMetaWithContext(context.Background(), projectKey, issueTypeName)
+}
+
+// GetEditMetaWithContext returns the edit metadata of the issue issueKey.
+// Edit metadata depends on the state of an issue, so it should be invalidated with InvalidateEditMeta after transitions.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getEditIssueMeta
+func (c *MetaCache) GetEditMetaWithContext(ctx context.Context, issueKey string) (*EditMetaInfo, error) {
+	c.mu.Lock()
+	entry, ok := c.editMeta[issueKey]
+	if ok && c.expired(entry.Fetched) {
+		delete(c.editMeta, issueKey)
+		ok = false
+	}
+	c.mu.Unlock()
+	if ok {
+		return entry.EditMeta, nil
+	}
+
+	meta, _, err := c.client.Issue.GetEditMetaWithContext(ctx, issueKey)
+	if err != nil {
+		return nil, err
+	}
+	entry = &editMetaEntry{EditMeta: meta, Fetched: c.now()}
+
+	c.mu.Lock()
+	c.editMeta[issueKey] = entry
+	err = c.save()
+	c.mu.Unlock()
+
+	return entry.EditMeta, err
+}
+
+// GetEditMeta wraps GetEditMetaWithContext using the background context.
+func (c *MetaCache) GetEditMeta(issueKey string) (*EditMetaInfo, error) {
+	return c.GetEditMetaWithContext(context.Background(), issueKey)
+}
+
+// Invalidate removes the create metadata of the issue type issueTypeName in the project projectKey from the cache.
+// If issueTypeName is empty, the metadata of all issue types of the project is removed.
+func (c *MetaCache) Invalidate(projectKey, issueTypeName string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if issueTypeName != "" {
+		delete(c.createMeta, createMetaKey(projectKey, issueTypeName))
+		return c.save()
+	}
+	prefix := createMetaKey(projectKey, "")
+	for key := range c.createMeta {
+		if strings.HasPrefix(key, prefix) {
+			delete(c.createMeta, key)
+		}
+	}
+	return c.save()
+}
+
+// InvalidateEditMeta removes the edit metadata of the issue issueKey from the cache.
+func (c *MetaCache) InvalidateEditMeta(issueKey string) error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	delete(c.editMeta, issueKey)
+	return c.save()
+}
+
+// InvalidateAll removes all metadata from the cache.
+func (c *MetaCache) InvalidateAll() error {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.createMeta = map[string]*createMetaEntry{}
+	c.editMeta = map[string]*editMetaEntry{}
+	return c.save()
+}
+
+// createMetaKey returns the cache key of an issue type.
+// Project keys can not contain a "/", so the keys of different projects never share a prefix.
+func createMetaKey(projectKey, issueTypeName string) string {
+	return strings.ToLower(projectKey) + "/" + strings.ToLower(issueTypeName)
+}
+
+// expired reports if metadata fetched at fetched has to be fetched again.
+func (c *MetaCache) expired(fetched time.Time) bool {
+	return c.ttl >= 0 && c.now().Sub(fetched) >= c.ttl
+}
+
+// load reads the persisted cache, if any. Expired entries are dropped.
+func (c *MetaCache) load() error {
+	if c.path == "" {
+		return nil
+	}
+	b, err := ioutil.ReadFile(c.path)
+	if os.IsNotExist(err) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+
+	var f metaCacheFile
+	if err := json.Unmarshal(b, &f); err != nil {
+		return fmt.Errorf("meta cache %s: %w", c.path, err)
+	}
+	for key, entry := range f.CreateMeta {
+		if entry != nil && entry.Project != nil && entry.IssueType != nil && !c.expired(entry.Fetched) {
+			c.createMeta[key] = entry
+		}
+	}
+	for key, entry := range f.EditMeta {
+		if entry != nil && entry.EditMeta != nil && !c.expired(entry.Fetched) {
+			c.editMeta[key] = entry
+		}
+	}
+	return nil
+}
+
+// save persists the cache, if a path is configured. c.mu must be held.
+// The file is replaced atomically, so other processes never read a partially written cache.
+func (c *MetaCache) save() error {
+	if c.path == "" {
+		return nil
+	}
+	b, err := json.Marshal(metaCacheFile{CreateMeta: c.createMeta, EditMeta: c.editMeta})
+	if err != nil {
+		return err
+	}
+
+	tmp, err := ioutil.TempFile(filepath.Dir(c.path), filepath.Base(c.path)+".tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), c.path)
+}
@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+)
+
+const testMetaCacheCreateMeta = `{
+	"projects": [{
+		"id": "11300",
+		"key": "SPN",
+		"name": "Super Project Name",
+		"issuetypes": [{
+			"id": "1",
+			"name": "Bug",
+			"fields": {
+				"summary": {"required": true, "name": "Summary", "schema": {"type": "string", "system": "summary"}}
+			}
+		}]
+	}]
+}`
+
+// setupMetaCacheMux registers handlers for the create and edit metadata and returns the number of calls to each.
+func setupMetaCacheMux(t *testing.T) (createCalls, editCalls *int) {
+	createCalls, editCalls = new(int), new(int)
+	var mu sync.Mutex
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/issue/createmeta?expand=projects.issuetypes.fields&issuetypeNames=Bug&projectKeys=SPN")
+		mu.Lock()
+		*createCalls++
+		mu.Unlock()
+		fmt.Fprint(w, testMetaCacheCreateMeta)
+	})
+	testMux.HandleFunc("/rest/api/2/issue/SPN-1/editmeta", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		mu.Lock()
+		*editCalls++
+		mu.Unlock()
+		fmt.Fprint(w, `{"fields": {"summary": {"required": true, "name": "Summary", "schema": {"type": "string"}, "operations": ["set"]}}}`)
+	})
+	return createCalls, editCalls
+}
+
+func TestMetaCache_GetCreateMeta(t *testing.T) {
+	setup()
+	defer teardown()
+	createCalls, _ := setupMetaCacheMux(t)
+
+	cache, err := NewMetaCache(testClient, nil)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	var wg sync.WaitGroup
+	for i := 0; i < 5; i++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			if _, _, err := cache.GetCreateMeta("SPN", "Bug"); err != nil {
+				t.Errorf("Error given: %s", err)
+			}
+		}()
+	}
+	wg.Wait()
+	calls := *createCalls
+
+	project, issueType, err := cache.GetCreateMeta("spn", "bug")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if *createCalls != calls {
+		t.Errorf("Expected the cached metadata to be used, got %d calls", *createCalls)
+	}
+	if project.Key != "SPN" || len(project.IssueTypes) != 0 {
+		t.Errorf("Unexpected project %+v", project)
+	}
+	if mandatory, _ := issueType.GetMandatoryFields(); mandatory["Summary"] != "summary" {
+		t.Errorf("Unexpected mandatory fields %v", mandatory)
+	}
+}
+
+func TestMetaCache_GetCreateMeta_UnknownIssueType(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/createmeta", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"projects": [{"key": "SPN", "issuetypes": []}]}`)
+	})
+
+	cache, _ := NewMetaCache(testClient, nil)
+	if _, _, err := cache.GetCreateMeta("SPN", "Epic"); err == nil {
+		t.Error("Expected an error for an unknown issue type")
+	}
+}
+
+func TestMetaCache_TTLAndInvalidate(t *testing.T) {
+	setup()
+	defer teardown()
+	createCalls, editCalls := setupMetaCacheMux(t)
+
+	cache, _ := NewMetaCache(testClient, &MetaCacheOptions{TTL: time.Minute})
+	now := time.Date(2017, 1, 1, 0, 0, 0, 0, time.UTC)
+	cache.now = func() time.Time { return now }
+
+	cache.GetCreateMeta("SPN", "Bug")
+	cache.GetEditMeta("SPN-1")
+	now = now.Add(30 * time.Second)
+	cache.GetCreateMeta("SPN", "Bug")
+	cache.GetEditMeta("SPN-1")
+	if *createCalls != 1 || *editCalls != 1 {
+		t.Errorf("Expected 1 call each before the TTL expired, got %d and %d", *createCalls, *editCalls)
+	}
+
+	now = now.Add(time.Minute)
+	cache.GetCreateMeta("SPN", "Bug")
+	cache.GetEditMeta("SPN-1")
+	if *createCalls != 2 || *editCalls != 2 {
+		t.Errorf("Expected 2 calls each after the TTL expired, got %d and %d", *createCalls, *editCalls)
+	}
+
+	cache.Invalidate("SPN", "")
+	cache.InvalidateEditMeta("SPN-1")
+	cache.GetCreateMeta("SPN", "Bug")
+	cache.GetEditMeta("SPN-1")
+	if *createCalls != 3 || *editCalls != 3 {
+		t.Errorf("Expected 3 calls each after the invalidation, got %d and %d", *createCalls, *editCalls)
+	}
+
+	cache.InvalidateAll()
+	cache.GetCreateMeta("SPN", "Bug")
+	if *createCalls != 4 {
+		t.Errorf("Expected 4 calls after the invalidation of all metadata, got %d", *createCalls)
+	}
+}
+
+func TestMetaCache_Persistence(t *testing.T) {
+	setup()
+	defer teardown()
+	createCalls, _ := setupMetaCacheMux(t)
+
+	dir, err := ioutil.TempDir("", "metacache")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	defer os.RemoveAll(dir)
+	path := filepath.Join(dir, "meta.json")
+
+	cache, err := NewMetaCache(testClient, &MetaCacheOptions{Path: path})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, _, err := cache.GetCreateMeta("SPN", "Bug"); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	// A second cache, e.g. of the next run of a program, is loaded from the file
+	cache, err = NewMetaCache(testClient, &MetaCacheOptions{Path: path})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	_, issueType, err := cache.GetCreateMeta("SPN", "Bug")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if *createCalls != 1 {
+		t.Errorf("Expected the persisted metadata to be used, got %d calls", *createCalls)
+	}
+	if field, _ := issueType.GetField("summary"); field == nil || !field.Required {
+		t.Errorf("Unexpected field %+v", field)
+	}
+
+	// Expired entries are not loaded
+	cache, err = NewMetaCache(testClient, &MetaCacheOptions{Path: path, TTL: time.Nanosecond})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	cache.GetCreateMeta("SPN", "Bug")
+	if *createCalls != 2 {
+		t.Errorf("Expected the expired metadata to be fetched again, got %d calls", *createCalls)
+	}
+}
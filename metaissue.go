@@ -1,6 +1,8 @@
 package jira
 
 import (
+	"context"
+	"encoding/json"
 	"fmt"
 	"strings"
 
@@ -16,7 +18,7 @@ type CreateMetaInfo struct {
 // MetaProject is the meta information about a project returned from createmeta api
 type MetaProject struct {
 	Expand string `json:"expand,omitempty"`
-	Self   string `json:"self, omitempty"`
+	Self   string `json:"self,omitempty"`
 	Id     string `json:"id,omitempty"`
 	Key    string `json:"key,omitempty"`
 	Name   string `json:"name,omitempty"`
@@ -29,31 +31,182 @@ type MetaProject struct {
 // Note: Fields is interface because this is an object which can
 // have arbitraty keys related to customfields. It is not possible to
 // expect these for a general way. This will be returning a map.
-// Further processing must be done depending on what is required.
+// Use GetFields or GetField to access the fields as FieldMeta.
 type MetaIssueType struct {
-	Self        string                `json:"expand,omitempty"`
+	Self        string                `json:"self,omitempty"`
 	Id          string                `json:"id,omitempty"`
 	Description string                `json:"description,omitempty"`
-	IconUrl     string                `json:"iconurl,omitempty"`
+	IconUrl     string                `json:"iconUrl,omitempty"`
 	Name        string                `json:"name,omitempty"`
 	Subtasks    bool                  `json:"subtask,omitempty"`
 	Expand      string                `json:"expand,omitempty"`
 	Fields      tcontainer.MarshalMap `json:"fields,omitempty"`
 }
 
-// GetCreateMeta makes the api call to get the meta information required to create a ticket
+// FieldMeta describes a field in the create or edit metadata of an issue type.
+type FieldMeta struct {
+	// Key is the id of the field, e.g. "summary" or "customfield_10002"
+	Key             string         `json:"key,omitempty"`
+	Required        bool           `json:"required"`
+	Name            string         `json:"name"`
+	Schema          FieldSchema    `json:"schema"`
+	Operations      []string       `json:"operations,omitempty"`
+	AllowedValues   []AllowedValue `json:"allowedValues,omitempty"`
+	HasDefaultValue bool           `json:"hasDefaultValue,omitempty"`
+	DefaultValue    interface{}    `json:"defaultValue,omitempty"`
+	AutoCompleteURL string         `json:"autoCompleteUrl,omitempty"`
+}
+
+// AllowedValue is one of the values a field with a fixed set of values accepts,
+// e.g. a priority, a version or an option of a select list.
+// Depending on the type of the field, a value is identified by ID, Key, Name or Value.
+type AllowedValue struct {
+	Self     string         `json:"self,omitempty"`
+	ID       string         `json:"id,omitempty"`
+	Key      string         `json:"key,omitempty"`
+	Name     string         `json:"name,omitempty"`
+	Value    string         `json:"value,omitempty"`
+	Disabled bool           `json:"disabled,omitempty"`
+	Children []AllowedValue `json:"children,omitempty"`
+}
+
+// UnmarshalJSON accepts allowed values given as objects or as plain strings.
+// A plain string is stored in Value.
+func (v *AllowedValue) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err == nil {
+		*v = AllowedValue{Value: s}
+		return nil
+	}
+
+	type allowedValue AllowedValue // prevents recursion
+	return json.Unmarshal(data, (*allowedValue)(v))
+}
+
+// IsCustom reports if the field is a custom field.
+func (f *FieldMeta) IsCustom() bool {
+	return f.Schema.Custom != ""
+}
+
+// IsArray reports if the field takes multiple values. The type of the values is Schema.Items.
+func (f *FieldMeta) IsArray() bool {
+	return f.Schema.Type == "array"
+}
+
+// HasOperation reports if the field supports the update operation op, e.g. "set" or "add".
+func (f *FieldMeta) HasOperation(op string) bool {
+	for _, o := range f.Operations {
+		if o == op {
+			return true
+		}
+	}
+	return false
+}
+
+// GetFields returns the metadata of all fields of the issue type, keyed by field id.
+func (t *MetaIssueType) GetFields() (map[string]*FieldMeta, error) {
+	return parseFieldMetas(t.Fields)
+}
+
+// GetField returns the metadata of the field with the id key, e.g. "summary" or "customfield_10002".
+// It returns nil if the issue type has no such field.
+func (t *MetaIssueType) GetField(key string) (*FieldMeta, error) {
+	return parseFieldMeta(t.Fields, key)
+}
+
+// GetFieldByName returns the metadata of the field with the given name, e.g. "Story Points".
+// The comparision of the name is case insensitive. It returns nil if the issue type has no such field.
+func (t *MetaIssueType) GetFieldByName(name string) (*FieldMeta, error) {
+	fields, err := t.GetFields()
+	if err != nil {
+		return nil, err
+	}
+	for _, f := range fields {
+		if strings.EqualFold(f.Name, name) {
+			return f, nil
+		}
+	}
+	return nil, nil
+}
+
+// parseFieldMetas converts all fields of raw metadata into FieldMeta.
+func parseFieldMetas(fields tcontainer.MarshalMap) (map[string]*FieldMeta, error) {
+	ret := make(map[string]*FieldMeta, len(fields))
+	for key := range fields {
+		f, err := parseFieldMeta(fields, key)
+		if err != nil {
+			return nil, err
+		}
+		ret[key] = f
+	}
+	return ret, nil
+}
+
+// parseFieldMeta converts the field key of raw metadata into a FieldMeta.
+// The property "name" is mandatory.
+func parseFieldMeta(fields tcontainer.MarshalMap, key string) (*FieldMeta, error) {
+	raw, ok := fields[key]
+	if !ok {
+		return nil, nil
+	}
+	if _, err := fields.String(key + "/name"); err != nil {
+		return nil, err
+	}
+
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("field %s: %w", key, err)
+	}
+	f := new(FieldMeta)
+	if err := json.Unmarshal(b, f); err != nil {
+		return nil, fmt.Errorf("field %s: %w", key, err)
+	}
+	f.Key = key
+	return f, nil
+}
+
+// GetCreateMetaOptions specifies the optional parameters to the GetCreateMetaWithOptions method
+type GetCreateMetaOptions struct {
+	// ProjectKeys limits the result to the given projects
+	ProjectKeys []string `url:"projectKeys,comma,omitempty"`
+	// IssueTypeNames limits the result to the issue types with the given names
+	IssueTypeNames []string `url:"issuetypeNames,comma,omitempty"`
+	// IssueTypeIDs limits the result to the issue types with the given ids
+	IssueTypeIDs []string `url:"issuetypeIds,comma,omitempty"`
+	// Expand: Use "projects.issuetypes.fields" to include the fields of the issue types
+	Expand string `url:"expand,omitempty"`
+}
+
+// GetCreateMetaWithContext makes the api call to get the meta information required to create a ticket
+func (s *IssueService) GetCreateMetaWithContext(ctx context.Context, projectkey string) (*CreateMetaInfo, *Response, error) {
+	return s.GetCreateMetaWithOptionsWithContext(ctx, &GetCreateMetaOptions{
+		ProjectKeys: []string{projectkey},
+		Expand:      "projects.issuetypes.fields",
+	})
+}
+
+// GetCreateMeta wraps GetCreateMetaWithContext using the background context.
 func (s *IssueService) GetCreateMeta(projectkey string) (*CreateMetaInfo, *Response, error) {
+	return s.GetCreateMetaWithContext(context.Background(), projectkey)
+}
 
-	apiEndpoint := fmt.Sprintf("/rest/api/2/issue/createmeta?projectKeys=%s&expand=projects.issuetypes.fields", projectkey)
+// GetCreateMetaWithOptionsWithContext makes the api call to get the meta information required to create a ticket,
+// limited to the projects and issue types given by options. options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getCreateIssueMeta
+func (s *IssueService) GetCreateMetaWithOptionsWithContext(ctx context.Context, options *GetCreateMetaOptions) (*CreateMetaInfo, *Response, error) {
+	apiEndpoint, err := addOptions("rest/api/2/issue/createmeta", options)
+	if err != nil {
+		return nil, nil, err
+	}
 
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
 
 	meta := new(CreateMetaInfo)
 	resp, err := s.client.Do(req, meta)
-
 	if err != nil {
 		return nil, resp, err
 	}
@@ -61,6 +214,11 @@ func (s *IssueService) GetCreateMeta(projectkey string) (*CreateMetaInfo, *Respo
 	return meta, resp, nil
 }
 
+// GetCreateMetaWithOptions wraps GetCreateMetaWithOptionsWithContext using the background context.
+func (s *IssueService) GetCreateMetaWithOptions(options *GetCreateMetaOptions) (*CreateMetaInfo, *Response, error) {
+	return s.GetCreateMetaWithOptionsWithContext(context.Background(), options)
+}
+
 // GetProjectWithName returns a project with "name" from the meta information recieved. If not found, this returns nil.
 // The comparision of the name is case insensitive.
 func (m *CreateMetaInfo) GetProjectWithName(name string) *MetaProject {
@@ -112,18 +270,18 @@ func (p *MetaProject) GetIssueTypeWithName(name string) *MetaIssueType {
 // the returned map would have "Epic Link" as the key and "customfield_10806" as value.
 // This choice has been made so that the it is easier to generate the create api request later.
 func (t *MetaIssueType) GetMandatoryFields() (map[string]string, error) {
+	fields, err := t.GetFields()
+	if err != nil {
+		return nil, err
+	}
 	ret := make(map[string]string)
-	for key, _ := range t.Fields {
-		required, err := t.Fields.Bool(key + "/required")
-		if err != nil {
+	for key, f := range fields {
+		// JIRA always sends "required", metadata without it is incomplete
+		if _, err := t.Fields.Bool(key + "/required"); err != nil {
 			return nil, err
 		}
-		if required {
-			name, err := t.Fields.String(key + "/name")
-			if err != nil {
-				return nil, err
-			}
-			ret[name] = key
+		if f.Required {
+			ret[f.Name] = key
 		}
 	}
 	return ret, nil
@@ -132,14 +290,13 @@ func (t *MetaIssueType) GetMandatoryFields() (map[string]string, error) {
 // GetAllFields returns a map of all the fields for an IssueType. This includes all required and not required.
 // The key of the returned map is what you see in the form and the value is how it is representated in the jira schema.
 func (t *MetaIssueType) GetAllFields() (map[string]string, error) {
+	fields, err := t.GetFields()
+	if err != nil {
+		return nil, err
+	}
 	ret := make(map[string]string)
-	for key, _ := range t.Fields {
-
-		name, err := t.Fields.String(key + "/name")
-		if err != nil {
-			return nil, err
-		}
-		ret[name] = key
+	for key, f := range fields {
+		ret[f.Name] = key
 	}
 	return ret, nil
 }
@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"encoding/json"
 	"fmt"
 	"net/http"
 	"testing"
@@ -378,6 +379,88 @@ func TestIssueService_GetCreateMeta_Success(t *testing.T) {
 
 }
 
+func TestMetaIssueType_GetField(t *testing.T) {
+	data := make(map[string]interface{})
+	data["priority"] = map[string]interface{}{
+		"required":        false,
+		"name":            "Priority",
+		"schema":          map[string]interface{}{"type": "priority", "system": "priority"},
+		"operations":      []interface{}{"set"},
+		"hasDefaultValue": true,
+		"defaultValue":    map[string]interface{}{"id": "3", "name": "Major"},
+		"allowedValues": []interface{}{
+			map[string]interface{}{"id": "1", "name": "Blocker"},
+			map[string]interface{}{"id": "3", "name": "Major"},
+		},
+	}
+	data["customfield_10002"] = map[string]interface{}{
+		"required":        true,
+		"name":            "Story Points",
+		"schema":          map[string]interface{}{"type": "number", "custom": "com.atlassian.jira.plugin.system.customfieldtypes:float", "customId": 10002},
+		"operations":      []interface{}{"set"},
+		"autoCompleteUrl": "",
+	}
+	data["labels"] = map[string]interface{}{
+		"required":        false,
+		"name":            "Labels",
+		"schema":          map[string]interface{}{"type": "array", "items": "string", "system": "labels"},
+		"operations":      []interface{}{"add", "set", "remove"},
+		"autoCompleteUrl": "https://my.jira.com/rest/api/1.0/labels/suggest?query=",
+	}
+
+	m := new(MetaIssueType)
+	m.Fields = data
+
+	priority, err := m.GetField("priority")
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if priority.Key != "priority" || priority.Schema.Type != "priority" || priority.IsCustom() {
+		t.Errorf("Unexpected field %+v", priority)
+	}
+	if len(priority.AllowedValues) != 2 || priority.AllowedValues[1].Name != "Major" {
+		t.Errorf("Expected 2 allowed values, recieved %+v", priority.AllowedValues)
+	}
+	if !priority.HasDefaultValue || priority.DefaultValue == nil {
+		t.Error("Expected a default value")
+	}
+
+	storyPoints, err := m.GetFieldByName("story points")
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if storyPoints == nil || storyPoints.Key != "customfield_10002" || !storyPoints.Required || !storyPoints.IsCustom() || storyPoints.Schema.CustomID != 10002 {
+		t.Errorf("Unexpected field %+v", storyPoints)
+	}
+
+	labels, _ := m.GetField("labels")
+	if !labels.IsArray() || labels.Schema.Items != "string" || !labels.HasOperation("add") || labels.HasOperation("edit") {
+		t.Errorf("Unexpected field %+v", labels)
+	}
+
+	if missing, err := m.GetField("customfield_99999"); missing != nil || err != nil {
+		t.Errorf("Expected nil for a missing field, recieved %+v, %v", missing, err)
+	}
+
+	fields, err := m.GetFields()
+	if err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if len(fields) != 3 {
+		t.Errorf("Expected 3 fields, recieved %d", len(fields))
+	}
+}
+
+func TestAllowedValue_UnmarshalJSON_String(t *testing.T) {
+	var values []AllowedValue
+	if err := json.Unmarshal([]byte(`["plain",{"id":"1","value":"option"}]`), &values); err != nil {
+		t.Fatalf("Expected nil error, recieved %s", err)
+	}
+	if values[0].Value != "plain" || values[1].ID != "1" || values[1].Value != "option" {
+		t.Errorf("Unexpected values %+v", values)
+	}
+}
+
 func TestMetaIssueType_GetMandatoryFields(t *testing.T) {
 	data := make(map[string]interface{})
 
@@ -570,7 +653,7 @@ func TestMetaIssueType_CheckCompleteAndAvailable_Success(t *testing.T) {
 
 	ok, err := m.CheckCompleteAndAvailable(config)
 	if err != nil {
-		t.Error("Expected nil error. Recieved %s", err)
+		t.Errorf("Expected nil error. Recieved %s", err)
 	}
 
 	if ok != true {
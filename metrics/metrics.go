@@ -0,0 +1,402 @@
+// Package metrics computes flow metrics like lead time, cycle time, time in status,
+// work in progress and throughput from the changelogs of JIRA issues.
+//
+// The status an issue had in the past is only known by name from the changelog.
+// Statuses are assigned to phases by the names given in Config, or otherwise by their status category.
+// The categories of the current statuses of the analyzed issues are picked up automatically,
+// categories of statuses no issue currently has can be added with Config.StatusCategories.
+//
+//	report, err := metrics.Fetch(ctx, client, "project = EX AND resolved >= -14d", metrics.Config{
+//		InProgressStatuses: []string{"In Progress", "In Review"},
+//	})
+//	fmt.Println(report.CycleTime.Percentiles[85])
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// Status category keys of JIRA
+const (
+	CategoryToDo       = "new"
+	CategoryInProgress = "indeterminate"
+	CategoryDone       = "done"
+)
+
+// DefaultPercentiles are used if Config.Percentiles is empty.
+var DefaultPercentiles = []float64{50, 85, 95}
+
+// Config controls how the metrics are computed.
+type Config struct {
+	// InProgressStatuses are the statuses in which an issue is worked on.
+	// The cycle time starts when an issue enters one of them for the first time.
+	// Defaults to all statuses of the status category "indeterminate".
+	InProgressStatuses []string
+	// DoneStatuses are the statuses in which an issue is finished.
+	// Defaults to all statuses of the status category "done".
+	DoneStatuses []string
+	// StatusCategories maps status names to status category keys ("new", "indeterminate" or "done").
+	// It is only needed for statuses none of the analyzed issues currently has.
+	StatusCategories map[string]string
+	// Percentiles of the lead and cycle time summaries, e.g. 85 for the 85th percentile.
+	// Defaults to DefaultPercentiles.
+	Percentiles []float64
+	// Interval is the length of the throughput buckets and the distance between WIP samples.
+	// Defaults to 24 hours.
+	Interval time.Duration
+	// From is the start of the throughput and WIP series.
+	// Defaults to the creation of the oldest issue.
+	From time.Time
+	// Now is the end of all open status periods and of the throughput and WIP series.
+	// Defaults to the current time.
+	Now time.Time
+}
+
+// phase is the stage of the workflow a status belongs to
+type phase int
+
+const (
+	phaseToDo phase = iota
+	phaseInProgress
+	phaseDone
+)
+
+// StatusPeriod is a span of time an issue spent in one status.
+type StatusPeriod struct {
+	Status string
+	Start  time.Time
+	// End is zero for the current status of the issue
+	End time.Time
+}
+
+// IssueMetrics are the metrics of a single issue.
+type IssueMetrics struct {
+	Key     string
+	Created time.Time
+	// Started is the time the issue entered an in progress status the first time, or zero.
+	Started time.Time
+	// Done is the time the issue entered its final done status, or zero if the issue is not done.
+	Done time.Time
+	// LeadTime is the time from Created to Done, or zero if the issue is not done.
+	LeadTime time.Duration
+	// CycleTime is the time from Started to Done, or zero if the issue is not done or was never started.
+	CycleTime time.Duration
+	// TimeInStatus sums up the time spent per status. The current status is counted until Config.Now.
+	TimeInStatus map[string]time.Duration
+	Periods      []StatusPeriod
+}
+
+// IsDone reports if the issue is finished.
+func (m *IssueMetrics) IsDone() bool {
+	return !m.Done.IsZero()
+}
+
+// Summary describes a distribution of durations.
+type Summary struct {
+	Count int
+	Min   time.Duration
+	Max   time.Duration
+	Mean  time.Duration
+	// Percentiles maps the configured percentiles to their values (nearest-rank method).
+	Percentiles map[float64]time.Duration
+}
+
+// Sample is the number of issues at a point in time or within an interval.
+type Sample struct {
+	Time  time.Time
+	Count int
+}
+
+// Report contains the metrics of a set of issues.
+type Report struct {
+	Issues    []IssueMetrics
+	LeadTime  Summary
+	CycleTime Summary
+	// TimeInStatus summarizes the time the issues spent in each status.
+	TimeInStatus map[string]Summary
+	// WIP is the number of issues in progress at the start of each interval.
+	WIP []Sample
+	// Throughput is the number of issues done within each interval, starting at Time.
+	Throughput []Sample
+}
+
+// Fetch searches all issues matching jql including their changelogs and computes the report.
+// Changelogs truncated by the search are fetched completely.
+func Fetch(ctx context.Context, client *jira.Client, jql string, config Config) (*Report, error) {
+	it := client.Issue.SearchIteratorWithContext(ctx, jql, &jira.IssueSearchOptions{
+		Expand: "changelog",
+		Fields: []string{"created", "status"},
+	})
+
+	var issues []jira.Issue
+	for it.Next() {
+		issue := it.Value()
+		if issue.Changelog != nil && len(issue.Changelog.Histories) < issue.Changelog.Total {
+			changelog, _, err := client.Issue.GetAllChangelogWithContext(ctx, issue.Key)
+			if err != nil {
+				return nil, fmt.Errorf("metrics: changelog of %s: %w", issue.Key, err)
+			}
+			issue.Changelog = changelog
+		}
+		issues = append(issues, issue)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("metrics: %w", err)
+	}
+
+	return Compute(issues, config)
+}
+
+// Compute calculates the report for issues.
+// The issues need the fields "created" and "status" and their changelog (expand=changelog).
+func Compute(issues []jira.Issue, config Config) (*Report, error) {
+	c := newCalculator(issues, config)
+
+	report := &Report{TimeInStatus: map[string]Summary{}}
+	for _, issue := range issues {
+		m, err := c.issueMetrics(issue)
+		if err != nil {
+			return nil, err
+		}
+		report.Issues = append(report.Issues, *m)
+	}
+
+	var leadTimes, cycleTimes []time.Duration
+	inStatus := map[string][]time.Duration{}
+	for _, m := range report.Issues {
+		if m.IsDone() {
+			leadTimes = append(leadTimes, m.LeadTime)
+			if !m.Started.IsZero() {
+				cycleTimes = append(cycleTimes, m.CycleTime)
+			}
+		}
+		for status, d := range m.TimeInStatus {
+			inStatus[status] = append(inStatus[status], d)
+		}
+	}
+	report.LeadTime = c.summarize(leadTimes)
+	report.CycleTime = c.summarize(cycleTimes)
+	for status, durations := range inStatus {
+		report.TimeInStatus[status] = c.summarize(durations)
+	}
+
+	report.WIP, report.Throughput = c.series(report.Issues)
+	return report, nil
+}
+
+// calculator holds the normalized configuration.
+type calculator struct {
+	config     Config
+	inProgress map[string]bool
+	done       map[string]bool
+	categories map[string]string
+}
+
+func newCalculator(issues []jira.Issue, config Config) *calculator {
+	if len(config.Percentiles) == 0 {
+		config.Percentiles = DefaultPercentiles
+	}
+	if config.Interval <= 0 {
+		config.Interval = 24 * time.Hour
+	}
+	if config.Now.IsZero() {
+		config.Now = time.Now()
+	}
+
+	c := &calculator{
+		config:     config,
+		inProgress: toSet(config.InProgressStatuses),
+		done:       toSet(config.DoneStatuses),
+		categories: map[string]string{},
+	}
+	for _, issue := range issues {
+		if issue.Fields != nil && issue.Fields.Status != nil && issue.Fields.Status.StatusCategory.Key != "" {
+			c.categories[strings.ToLower(issue.Fields.Status.Name)] = issue.Fields.Status.StatusCategory.Key
+		}
+	}
+	for status, category := range config.StatusCategories {
+		c.categories[strings.ToLower(status)] = category
+	}
+	return c
+}
+
+func toSet(statuses []string) map[string]bool {
+	set := make(map[string]bool, len(statuses))
+	for _, status := range statuses {
+		set[strings.ToLower(status)] = true
+	}
+	return set
+}
+
+// phase returns the workflow stage of status.
+func (c *calculator) phase(status string) phase {
+	status = strings.ToLower(status)
+	category := c.categories[status]
+
+	switch {
+	case c.done[status], len(c.done) == 0 && category == CategoryDone:
+		return phaseDone
+	case c.inProgress[status], len(c.inProgress) == 0 && category == CategoryInProgress:
+		return phaseInProgress
+	}
+	return phaseToDo
+}
+
+// issueMetrics computes the metrics of a single issue.
+func (c *calculator) issueMetrics(issue jira.Issue) (*IssueMetrics, error) {
+	if issue.Fields == nil {
+		return nil, fmt.Errorf("metrics: %s: fields \"created\" and \"status\" are required", issue.Key)
+	}
+	if issue.Fields.Created == nil || time.Time(*issue.Fields.Created).IsZero() {
+		return nil, fmt.Errorf("metrics: %s: field \"created\" is required", issue.Key)
+	}
+	created := time.Time(*issue.Fields.Created)
+
+	periods, err := statusPeriods(issue, created)
+	if err != nil {
+		return nil, fmt.Errorf("metrics: %s: %w", issue.Key, err)
+	}
+
+	m := &IssueMetrics{
+		Key:          issue.Key,
+		Created:      created,
+		Periods:      periods,
+		TimeInStatus: map[string]time.Duration{},
+	}
+	for _, p := range periods {
+		end := p.End
+		if end.IsZero() {
+			end = c.config.Now
+		}
+		if end.After(p.Start) {
+			m.TimeInStatus[p.Status] += end.Sub(p.Start)
+		}
+		if m.Started.IsZero() && c.phase(p.Status) == phaseInProgress {
+			m.Started = p.Start
+		}
+	}
+
+	// The issue is done since the start of the final sequence of done statuses, e.g. Resolved -> Closed
+	for i := len(periods) - 1; i >= 0 && c.phase(periods[i].Status) == phaseDone; i-- {
+		m.Done = periods[i].Start
+	}
+	if m.IsDone() {
+		m.LeadTime = m.Done.Sub(created)
+		if !m.Started.IsZero() {
+			m.CycleTime = m.Done.Sub(m.Started)
+		}
+	}
+	return m, nil
+}
+
+// statusPeriods reconstructs the statuses of issue from its creation until now.
+func statusPeriods(issue jira.Issue, created time.Time) ([]StatusPeriod, error) {
+	var transitions []jira.StatusTransition
+	if issue.Changelog != nil {
+		var err error
+		if transitions, err = issue.Changelog.StatusTransitions(); err != nil {
+			return nil, err
+		}
+	}
+
+	current := ""
+	if issue.Fields.Status != nil {
+		current = issue.Fields.Status.Name
+	}
+	if len(transitions) == 0 {
+		return []StatusPeriod{{Status: current, Start: created}}, nil
+	}
+
+	periods := []StatusPeriod{{Status: transitions[0].From, Start: created}}
+	for _, t := range transitions {
+		periods[len(periods)-1].End = t.Time
+		periods = append(periods, StatusPeriod{Status: t.To, Start: t.Time})
+	}
+	return periods, nil
+}
+
+// summarize computes the summary of durations.
+func (c *calculator) summarize(durations []time.Duration) Summary {
+	s := Summary{Count: len(durations), Percentiles: map[float64]time.Duration{}}
+	if len(durations) == 0 {
+		return s
+	}
+
+	sorted := append([]time.Duration(nil), durations...)
+	sort.Slice(sorted, func(i, j int) bool { return sorted[i] < sorted[j] })
+
+	var total time.Duration
+	for _, d := range sorted {
+		total += d
+	}
+	s.Min = sorted[0]
+	s.Max = sorted[len(sorted)-1]
+	s.Mean = total / time.Duration(len(sorted))
+	for _, p := range c.config.Percentiles {
+		s.Percentiles[p] = percentile(sorted, p)
+	}
+	return s
+}
+
+// percentile returns the p-th percentile of sorted using the nearest-rank method.
+func percentile(sorted []time.Duration, p float64) time.Duration {
+	rank := int(math.Ceil(p / 100 * float64(len(sorted))))
+	if rank < 1 {
+		rank = 1
+	}
+	if rank > len(sorted) {
+		rank = len(sorted)
+	}
+	return sorted[rank-1]
+}
+
+// series computes the WIP and throughput series from Config.From to Config.Now.
+func (c *calculator) series(issues []IssueMetrics) (wip, throughput []Sample) {
+	from := c.config.From
+	if from.IsZero() {
+		for _, m := range issues {
+			if from.IsZero() || m.Created.Before(from) {
+				from = m.Created
+			}
+		}
+	}
+	if from.IsZero() {
+		return nil, nil
+	}
+
+	for start := from; !start.After(c.config.Now); start = start.Add(c.config.Interval) {
+		end := start.Add(c.config.Interval)
+		w, t := Sample{Time: start}, Sample{Time: start}
+		for _, m := range issues {
+			if c.inProgressAt(m, start) {
+				w.Count++
+			}
+			if m.IsDone() && !m.Done.Before(start) && m.Done.Before(end) {
+				t.Count++
+			}
+		}
+		wip = append(wip, w)
+		throughput = append(throughput, t)
+	}
+	return wip, throughput
+}
+
+// inProgressAt reports if the issue was in an in progress status at t.
+func (c *calculator) inProgressAt(m IssueMetrics, t time.Time) bool {
+	for _, p := range m.Periods {
+		if p.Start.After(t) {
+			return false
+		}
+		if p.End.IsZero() || p.End.After(t) {
+			return c.phase(p.Status) == phaseInProgress
+		}
+	}
+	return false
+}
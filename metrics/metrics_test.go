@@ -0,0 +1,132 @@
+package metrics
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// Three issues, created on March 1st:
+//   - EX-1: Open -> In Progress (3rd) -> In Review (5th) -> Done (6th)
+//   - EX-2: Open -> In Progress (2nd) -> Done (10th)
+//   - EX-3: Open -> In Progress (4th), still in progress
+const testIssues = `[
+	{"key":"EX-1","fields":{"created":"2016-03-01T00:00:00.000+0000","status":{"name":"Done","statusCategory":{"key":"done"}}},"changelog":{"histories":[
+		{"id":"1","created":"2016-03-03T00:00:00.000+0000","items":[{"field":"status","fromString":"Open","toString":"In Progress"}]},
+		{"id":"2","created":"2016-03-05T00:00:00.000+0000","items":[{"field":"status","fromString":"In Progress","toString":"In Review"}]},
+		{"id":"3","created":"2016-03-06T00:00:00.000+0000","items":[{"field":"status","fromString":"In Review","toString":"Done"}]}
+	]}},
+	{"key":"EX-2","fields":{"created":"2016-03-01T00:00:00.000+0000","status":{"name":"Done","statusCategory":{"key":"done"}}},"changelog":{"histories":[
+		{"id":"4","created":"2016-03-02T00:00:00.000+0000","items":[{"field":"status","fromString":"Open","toString":"In Progress"}]},
+		{"id":"5","created":"2016-03-10T00:00:00.000+0000","items":[{"field":"status","fromString":"In Progress","toString":"Done"}]}
+	]}},
+	{"key":"EX-3","fields":{"created":"2016-03-01T00:00:00.000+0000","status":{"name":"In Progress","statusCategory":{"key":"indeterminate"}}},"changelog":{"histories":[
+		{"id":"6","created":"2016-03-04T00:00:00.000+0000","items":[{"field":"status","fromString":"Open","toString":"In Progress"}]}
+	]}}
+]`
+
+const day = 24 * time.Hour
+
+func testReport(t *testing.T, config Config) *Report {
+	var issues []jira.Issue
+	if err := json.Unmarshal([]byte(testIssues), &issues); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	config.Now = time.Date(2016, 3, 11, 0, 0, 0, 0, time.UTC)
+
+	report, err := Compute(issues, config)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	return report
+}
+
+func TestCompute_IssueMetrics(t *testing.T) {
+	report := testReport(t, Config{})
+
+	ex1 := report.Issues[0]
+	if ex1.LeadTime != 5*day {
+		t.Errorf("Expected lead time of 5 days. Got %s", ex1.LeadTime)
+	}
+	// "In Review" has no known category and is therefore not counted as in progress
+	if ex1.CycleTime != 3*day {
+		t.Errorf("Expected cycle time of 3 days. Got %s", ex1.CycleTime)
+	}
+	if ex1.TimeInStatus["In Review"] != day {
+		t.Errorf("Expected 1 day in review. Got %s", ex1.TimeInStatus["In Review"])
+	}
+
+	ex3 := report.Issues[2]
+	if ex3.IsDone() || ex3.LeadTime != 0 {
+		t.Errorf("Expected EX-3 not to be done. Got %+v", ex3)
+	}
+	if ex3.TimeInStatus["In Progress"] != 7*day {
+		t.Errorf("Expected 7 days in progress until now. Got %s", ex3.TimeInStatus["In Progress"])
+	}
+}
+
+func TestCompute_Summaries(t *testing.T) {
+	report := testReport(t, Config{Percentiles: []float64{50, 100}})
+
+	if report.LeadTime.Count != 2 || report.LeadTime.Min != 5*day || report.LeadTime.Max != 9*day || report.LeadTime.Mean != 7*day {
+		t.Errorf("Unexpected lead time summary %+v", report.LeadTime)
+	}
+	if got := report.LeadTime.Percentiles[50]; got != 5*day {
+		t.Errorf("Expected 50th percentile of 5 days. Got %s", got)
+	}
+	if got := report.CycleTime.Percentiles[100]; got != 8*day {
+		t.Errorf("Expected 100th percentile of 8 days. Got %s", got)
+	}
+	if got := report.TimeInStatus["Open"]; got.Count != 3 || got.Max != 3*day {
+		t.Errorf("Unexpected summary of Open %+v", got)
+	}
+}
+
+func TestCompute_StatusMapping(t *testing.T) {
+	report := testReport(t, Config{InProgressStatuses: []string{"in progress", "In Review"}})
+	if got := report.Issues[0].CycleTime; got != 3*day {
+		t.Errorf("Expected cycle time of 3 days. Got %s", got)
+	}
+
+	// Reviewed issues count as done
+	report = testReport(t, Config{DoneStatuses: []string{"In Review", "Done"}})
+	if got := report.Issues[0].LeadTime; got != 4*day {
+		t.Errorf("Expected lead time of 4 days. Got %s", got)
+	}
+}
+
+func TestCompute_Series(t *testing.T) {
+	report := testReport(t, Config{Interval: 2 * day})
+
+	// Samples at March 1st, 3rd, 5th, 7th, 9th and 11th
+	wantWIP := []int{0, 2, 2, 2, 2, 1}
+	wantThroughput := []int{0, 0, 1, 0, 1, 0}
+	if len(report.WIP) != len(wantWIP) {
+		t.Fatalf("Expected %d samples. Got %d", len(wantWIP), len(report.WIP))
+	}
+	for i := range wantWIP {
+		if report.WIP[i].Count != wantWIP[i] {
+			t.Errorf("WIP at %s: Expected %d. Got %d", report.WIP[i].Time, wantWIP[i], report.WIP[i].Count)
+		}
+		if report.Throughput[i].Count != wantThroughput[i] {
+			t.Errorf("Throughput at %s: Expected %d. Got %d", report.Throughput[i].Time, wantThroughput[i], report.Throughput[i].Count)
+		}
+	}
+}
+
+func TestCompute_MissingFields(t *testing.T) {
+	if _, err := Compute([]jira.Issue{{Key: "EX-1"}}, Config{}); err == nil {
+		t.Error("Expected an error for an issue without fields")
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	sorted := []time.Duration{1, 2, 3, 4, 5, 6, 7, 8, 9, 10}
+	for p, want := range map[float64]time.Duration{0: 1, 50: 5, 85: 9, 95: 10, 100: 10} {
+		if got := percentile(sorted, p); got != want {
+			t.Errorf("percentile(%v): Expected %d. Got %d", p, want, got)
+		}
+	}
+}
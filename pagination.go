@@ -0,0 +1,310 @@
+package jira
+
+import (
+	"context"
+)
+
+// defaultPageSize is used if no MaxResults are given to an iterator.
+// This is the default page size of most JIRA endpoints.
+const defaultPageSize = 50
+
+// pagingStyle describes how the last page of a paginated resource is detected.
+type pagingStyle int
+
+const (
+	// pagingByTotal is used by the api/2 endpoints.
+	// The last page is reached once startAt + size of the page >= total.
+	pagingByTotal pagingStyle = iota
+	// pagingByIsLast is used by the agile/1.0 endpoints.
+	// The last page is flagged with isLast.
+	pagingByIsLast
+)
+
+// pager walks through the pages of a paginated resource.
+// fetch requests the page starting at startAt, stores its items and returns the number of items.
+type pager struct {
+	ctx     context.Context
+	style   pagingStyle
+	startAt int
+	fetch   func(ctx context.Context, startAt int) (int, *Response, error)
+
+	done bool
+	resp *Response
+	err  error
+}
+
+// nextPage fetches the next page.
+// It returns false if there are no more items or an error occurred.
+func (p *pager) nextPage() bool {
+	if p.done || p.err != nil {
+		return false
+	}
+
+	n, resp, err := p.fetch(p.ctx, p.startAt)
+	p.resp = resp
+	if err != nil {
+		p.err = err
+		return false
+	}
+
+	p.startAt += n
+	switch p.style {
+	case pagingByIsLast:
+		p.done = resp.IsLast
+	default:
+		p.done = p.startAt >= resp.Total
+	}
+	if n == 0 {
+		// Protection against endless loops if the reported paging information is inconsistent
+		p.done = true
+		return false
+	}
+	return true
+}
+
+// Err returns the first error that occurred while fetching a page.
+func (p *pager) Err() error {
+	return p.err
+}
+
+// Response returns the Response of the last fetched page.
+func (p *pager) Response() *Response {
+	return p.resp
+}
+
+func pageSize(maxResults int) int {
+	if maxResults <= 0 {
+		return defaultPageSize
+	}
+	return maxResults
+}
+
+// SearchIterator walks through all issues matching a JQL query, page by page.
+//
+//	it := client.Issue.SearchIterator("project = MESOS", nil)
+//	for it.Next() {
+//		issue := it.Value()
+//		// ...
+//	}
+//	if err := it.Err(); err != nil {
+//		// ...
+//	}
+type SearchIterator struct {
+	pager
+	issues []Issue
+	index  int
+}
+
+// Next advances the iterator to the next issue. The next page is fetched if required.
+// It returns false when all issues were visited or an error occurred.
+func (it *SearchIterator) Next() bool {
+	it.index++
+	for it.index >= len(it.issues) {
+		if !it.nextPage() {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Value returns the current issue.
+func (it *SearchIterator) Value() Issue {
+	return it.issues[it.index]
+}
+
+// SearchIteratorWithContext returns a SearchIterator for all issues matching jql.
+// StartAt and MaxResults of options are used for the first page, the following pages are requested with the same size.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/search
+func (s *IssueService) SearchIteratorWithContext(ctx context.Context, jql string, options *IssueSearchOptions) *SearchIterator {
+	opt := IssueSearchOptions{}
+	if options != nil {
+		opt = *options
+	}
+	opt.MaxResults = pageSize(opt.MaxResults)
+
+	it := &SearchIterator{index: -1}
+	it.pager = pager{
+		ctx:     ctx,
+		style:   pagingByTotal,
+		startAt: opt.StartAt,
+		fetch: func(ctx context.Context, startAt int) (int, *Response, error) {
+			opt.StartAt = startAt
+			issues, resp, err := s.SearchWithOptionsWithContext(ctx, jql, &opt)
+			it.issues = issues
+			return len(issues), resp, err
+		},
+	}
+	return it
+}
+
+// SearchIterator wraps SearchIteratorWithContext using the background context.
+func (s *IssueService) SearchIterator(jql string, options *IssueSearchOptions) *SearchIterator {
+	return s.SearchIteratorWithContext(context.Background(), jql, options)
+}
+
+// BoardIterator walks through all agile boards, page by page.
+type BoardIterator struct {
+	pager
+	boards []Board
+	index  int
+}
+
+// Next advances the iterator to the next board. The next page is fetched if required.
+// It returns false when all boards were visited or an error occurred.
+func (it *BoardIterator) Next() bool {
+	it.index++
+	for it.index >= len(it.boards) {
+		if !it.nextPage() {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Value returns the current board.
+func (it *BoardIterator) Value() Board {
+	return it.boards[it.index]
+}
+
+// GetAllBoardsIteratorWithContext returns a BoardIterator for all boards matching the filters of options.
+//
+// JIRA API docs: https://docs.atlassian.com/jira-software/REST/cloud/#agile/1.0/board-getAllBoards
+func (s *BoardService) GetAllBoardsIteratorWithContext(ctx context.Context, options *BoardListOptions) *BoardIterator {
+	opt := BoardListOptions{}
+	if options != nil {
+		opt = *options
+	}
+
+	it := &BoardIterator{index: -1}
+	it.pager = pager{
+		ctx:     ctx,
+		style:   pagingByIsLast,
+		startAt: opt.StartAt,
+		fetch: func(ctx context.Context, startAt int) (int, *Response, error) {
+			opt.StartAt = startAt
+			boards, resp, err := s.GetAllBoardsWithContext(ctx, &opt)
+			if err != nil {
+				return 0, resp, err
+			}
+			it.boards = boards.Values
+			return len(boards.Values), resp, nil
+		},
+	}
+	return it
+}
+
+// GetAllBoardsIterator wraps GetAllBoardsIteratorWithContext using the background context.
+func (s *BoardService) GetAllBoardsIterator(options *BoardListOptions) *BoardIterator {
+	return s.GetAllBoardsIteratorWithContext(context.Background(), options)
+}
+
+// SprintIterator walks through all sprints of a board, page by page.
+type SprintIterator struct {
+	pager
+	sprints []Sprint
+	index   int
+}
+
+// Next advances the iterator to the next sprint. The next page is fetched if required.
+// It returns false when all sprints were visited or an error occurred.
+func (it *SprintIterator) Next() bool {
+	it.index++
+	for it.index >= len(it.sprints) {
+		if !it.nextPage() {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Value returns the current sprint.
+func (it *SprintIterator) Value() Sprint {
+	return it.sprints[it.index]
+}
+
+// GetAllSprintsIteratorWithContext returns a SprintIterator for all sprints of the board boardID matching the filters of options.
+//
+// JIRA API docs: https://docs.atlassian.com/jira-software/REST/cloud/#agile/1.0/board/{boardId}/sprint
+func (s *BoardService) GetAllSprintsIteratorWithContext(ctx context.Context, boardID string, options *GetAllSprintsOptions) *SprintIterator {
+	opt := GetAllSprintsOptions{}
+	if options != nil {
+		opt = *options
+	}
+
+	it := &SprintIterator{index: -1}
+	it.pager = pager{
+		ctx:     ctx,
+		style:   pagingByIsLast,
+		startAt: opt.StartAt,
+		fetch: func(ctx context.Context, startAt int) (int, *Response, error) {
+			opt.StartAt = startAt
+			sprints, resp, err := s.GetAllSprintsWithOptionsWithContext(ctx, boardID, &opt)
+			it.sprints = sprints
+			return len(sprints), resp, err
+		},
+	}
+	return it
+}
+
+// GetAllSprintsIterator wraps GetAllSprintsIteratorWithContext using the background context.
+func (s *BoardService) GetAllSprintsIterator(boardID string, options *GetAllSprintsOptions) *SprintIterator {
+	return s.GetAllSprintsIteratorWithContext(context.Background(), boardID, options)
+}
+
+// SprintIssueIterator walks through all issues of a sprint, page by page.
+type SprintIssueIterator struct {
+	pager
+	issues []Issue
+	index  int
+}
+
+// Next advances the iterator to the next issue. The next page is fetched if required.
+// It returns false when all issues were visited or an error occurred.
+func (it *SprintIssueIterator) Next() bool {
+	it.index++
+	for it.index >= len(it.issues) {
+		if !it.nextPage() {
+			return false
+		}
+		it.index = 0
+	}
+	return true
+}
+
+// Value returns the current issue.
+func (it *SprintIssueIterator) Value() Issue {
+	return it.issues[it.index]
+}
+
+// GetIssuesForSprintIteratorWithContext returns a SprintIssueIterator for all issues in the sprint sprintID.
+//
+// JIRA API docs: https://docs.atlassian.com/jira-software/REST/cloud/#agile/1.0/sprint-getIssuesForSprint
+func (s *SprintService) GetIssuesForSprintIteratorWithContext(ctx context.Context, sprintID int, options *SearchOptions) *SprintIssueIterator {
+	opt := SearchOptions{}
+	if options != nil {
+		opt = *options
+	}
+
+	it := &SprintIssueIterator{index: -1}
+	it.pager = pager{
+		ctx:     ctx,
+		style:   pagingByTotal,
+		startAt: opt.StartAt,
+		fetch: func(ctx context.Context, startAt int) (int, *Response, error) {
+			opt.StartAt = startAt
+			issues, resp, err := s.GetIssuesForSprintWithOptionsWithContext(ctx, sprintID, &opt)
+			it.issues = issues
+			return len(issues), resp, err
+		},
+	}
+	return it
+}
+
+// GetIssuesForSprintIterator wraps GetIssuesForSprintIteratorWithContext using the background context.
+func (s *SprintService) GetIssuesForSprintIterator(sprintID int, options *SearchOptions) *SprintIssueIterator {
+	return s.GetIssuesForSprintIteratorWithContext(context.Background(), sprintID, options)
+}
@@ -0,0 +1,172 @@
+package jira
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"testing"
+)
+
+func TestIssueService_SearchIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	requests := 0
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		requests++
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		if maxResults := r.URL.Query().Get("maxResults"); maxResults != "2" {
+			t.Errorf("Expected maxResults 2. Got %s", maxResults)
+		}
+
+		var issues []string
+		for i := startAt; i < startAt+2 && i < 5; i++ {
+			issues = append(issues, fmt.Sprintf(`{"key":"TEST-%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":2,"total":5,"issues":[%s]}`, startAt, strings.Join(issues, ","))
+	})
+
+	it := testClient.Issue.SearchIterator("type = Bug", &IssueSearchOptions{MaxResults: 2})
+	var keys []string
+	for it.Next() {
+		keys = append(keys, it.Value().Key)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	if want := "TEST-0,TEST-1,TEST-2,TEST-3,TEST-4"; strings.Join(keys, ",") != want {
+		t.Errorf("Expected issues %s. Got %s", want, strings.Join(keys, ","))
+	}
+	if requests != 3 {
+		t.Errorf("Expected 3 requests. Got %d", requests)
+	}
+	if resp := it.Response(); resp.StartAt != 4 || resp.Total != 5 {
+		t.Errorf("Expected paging info of the last page. Got StartAt %d, Total %d", resp.StartAt, resp.Total)
+	}
+}
+
+func TestIssueService_SearchIterator_Error(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Query().Get("startAt") == "0" {
+			fmt.Fprint(w, `{"startAt":0,"maxResults":1,"total":2,"issues":[{"key":"TEST-0"}]}`)
+			return
+		}
+		w.WriteHeader(http.StatusBadRequest)
+		fmt.Fprint(w, `{"errorMessages":["The value 'X' does not exist for the field 'project'."]}`)
+	})
+
+	it := testClient.Issue.SearchIterator("project = X", &IssueSearchOptions{MaxResults: 1})
+	count := 0
+	for it.Next() {
+		count++
+	}
+	if count != 1 {
+		t.Errorf("Expected 1 issue before the error. Got %d", count)
+	}
+	if !IsBadRequest(it.Err()) {
+		t.Errorf("Expected a bad request error. Got %+v", it.Err())
+	}
+}
+
+func TestBoardService_GetAllBoardsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/agile/1.0/board", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if r.URL.Query().Get("type") != "" {
+			t.Error("Unexpected filter")
+		}
+		switch r.URL.Query().Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"maxResults":2,"startAt":0,"isLast":false,"values":[{"id":1},{"id":2}]}`)
+		case "2":
+			fmt.Fprint(w, `{"maxResults":2,"startAt":2,"isLast":true,"values":[{"id":3}]}`)
+		default:
+			t.Errorf("Unexpected startAt %s", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	it := testClient.Board.GetAllBoardsIterator(nil)
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if len(ids) != 3 || ids[2] != 3 {
+		t.Errorf("Expected boards 1, 2, 3. Got %v", ids)
+	}
+	if !it.Response().IsLast {
+		t.Error("Expected IsLast to be populated in the response")
+	}
+}
+
+func TestBoardService_GetAllSprintsIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/agile/1.0/board/123/sprint", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		if state := r.URL.Query().Get("state"); state != "active,future" {
+			t.Errorf("Expected state filter active,future. Got %s", state)
+		}
+		switch r.URL.Query().Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"maxResults":1,"startAt":0,"isLast":false,"values":[{"id":740,"state":"active"}]}`)
+		case "1":
+			fmt.Fprint(w, `{"maxResults":1,"startAt":1,"isLast":true,"values":[{"id":741,"state":"future"}]}`)
+		default:
+			t.Errorf("Unexpected startAt %s", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	it := testClient.Board.GetAllSprintsIterator("123", &GetAllSprintsOptions{State: "active,future"})
+	var ids []int
+	for it.Next() {
+		ids = append(ids, it.Value().ID)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if len(ids) != 2 || ids[0] != 740 || ids[1] != 741 {
+		t.Errorf("Expected sprints 740, 741. Got %v", ids)
+	}
+}
+
+func TestSprintService_GetIssuesForSprintIterator(t *testing.T) {
+	setup()
+	defer teardown()
+
+	testMux.HandleFunc("/rest/agile/1.0/sprint/123/issue", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		switch r.URL.Query().Get("startAt") {
+		case "":
+			fmt.Fprint(w, `{"startAt":0,"maxResults":1,"total":2,"issues":[{"key":"TEST-1"}]}`)
+		case "1":
+			fmt.Fprint(w, `{"startAt":1,"maxResults":1,"total":2,"issues":[{"key":"TEST-2"}]}`)
+		default:
+			t.Errorf("Unexpected startAt %s", r.URL.Query().Get("startAt"))
+		}
+	})
+
+	it := testClient.Sprint.GetIssuesForSprintIterator(123, nil)
+	var keys []string
+	for it.Next() {
+		keys = append(keys, it.Value().Key)
+	}
+	if err := it.Err(); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if strings.Join(keys, ",") != "TEST-1,TEST-2" {
+		t.Errorf("Expected issues TEST-1,TEST-2. Got %v", keys)
+	}
+}
@@ -0,0 +1,185 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+)
+
+// defaultSearchWorkers is the number of pages fetched in parallel if SearchConcurrentOptions.Workers is not set.
+const defaultSearchWorkers = 4
+
+// SearchConcurrentOptions specifies the optional parameters to IssueService.SearchConcurrently.
+type SearchConcurrentOptions struct {
+	// IssueSearchOptions are used for every page.
+	// StartAt is the index of the first issue, MaxResults is the size of each page.
+	IssueSearchOptions
+
+	// Workers is the maximum number of pages fetched in parallel. Default: 4.
+	// At most Workers pages are kept in memory at the same time.
+	Workers int
+}
+
+// SearchPageError reports that a single page of a concurrent search could not be fetched.
+type SearchPageError struct {
+	// StartAt is the index of the first issue of the missing page
+	StartAt int
+	// MaxResults is the size of the missing page
+	MaxResults int
+	Err        error
+}
+
+func (e *SearchPageError) Error() string {
+	return fmt.Sprintf("Page starting at %d failed: %s", e.StartAt, e.Err)
+}
+
+// Unwrap returns the underlying error.
+func (e *SearchPageError) Unwrap() error {
+	return e.Err
+}
+
+// SearchPagesError aggregates all pages of a concurrent search that could not be fetched.
+// The issues of all other pages have been delivered.
+type SearchPagesError []*SearchPageError
+
+func (e SearchPagesError) Error() string {
+	msgs := make([]string, 0, len(e))
+	for _, pageErr := range e {
+		msgs = append(msgs, pageErr.Error())
+	}
+	return fmt.Sprintf("%d page(s) of the search failed: %s", len(e), strings.Join(msgs, "; "))
+}
+
+// Is reports if the error of any failed page matches target.
+// It lets errors.Is look into the pages, e.g. errors.Is(err, context.Canceled).
+func (e SearchPagesError) Is(target error) bool {
+	for _, pageErr := range e {
+		if errors.Is(pageErr, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// As finds the first error of the failed pages that matches target.
+// It lets errors.As look into the pages, e.g. to get the *ErrorResponse of a failed page.
+func (e SearchPagesError) As(target interface{}) bool {
+	for _, pageErr := range e {
+		if errors.As(pageErr, target) {
+			return true
+		}
+	}
+	return false
+}
+
+// searchPage is the result of fetching a single page
+type searchPage struct {
+	issues []Issue
+	err    error
+}
+
+// SearchConcurrentlyWithContext searches for all issues matching jql and calls fn for every issue in the order JIRA returned them.
+// The first page is fetched on its own to learn the total number of issues.
+// All remaining pages are fetched in parallel by up to options.Workers workers.
+//
+// If fn returns an error, the search is cancelled and the error is returned.
+// If the first page fails, its error is returned.
+// If any later page fails, the remaining pages are still delivered and a SearchPagesError listing the failed pages is returned.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/search
+func (s *IssueService) SearchConcurrentlyWithContext(ctx context.Context, jql string, options *SearchConcurrentOptions, fn func(Issue) error) error {
+	opt := SearchConcurrentOptions{}
+	if options != nil {
+		opt = *options
+	}
+	opt.MaxResults = pageSize(opt.MaxResults)
+	workers := opt.Workers
+	if workers <= 0 {
+		workers = defaultSearchWorkers
+	}
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	first := opt.IssueSearchOptions
+	issues, resp, err := s.SearchWithOptionsWithContext(ctx, jql, &first)
+	if err != nil {
+		return err
+	}
+	for _, issue := range issues {
+		if err := fn(issue); err != nil {
+			return err
+		}
+	}
+
+	// JIRA may limit the page size below the requested MaxResults
+	size := opt.MaxResults
+	if resp.MaxResults > 0 && resp.MaxResults < size {
+		size = resp.MaxResults
+	}
+	var starts []int
+	for startAt := opt.StartAt + len(issues); len(issues) > 0 && startAt < resp.Total; startAt += size {
+		starts = append(starts, startAt)
+	}
+
+	pages := make([]chan searchPage, len(starts))
+	for i := range pages {
+		pages[i] = make(chan searchPage, 1)
+	}
+
+	// A slot is taken before a page is fetched and released after the page was delivered.
+	// This limits the number of parallel requests as well as the number of buffered pages.
+	slots := make(chan struct{}, workers)
+	go func() {
+		for i, startAt := range starts {
+			select {
+			case slots <- struct{}{}:
+			case <-ctx.Done():
+				return
+			}
+
+			go func(page chan<- searchPage, startAt int) {
+				pageOpt := opt.IssueSearchOptions
+				pageOpt.StartAt = startAt
+				pageOpt.MaxResults = size
+				issues, _, err := s.SearchWithOptionsWithContext(ctx, jql, &pageOpt)
+				page <- searchPage{issues: issues, err: err}
+			}(pages[i], startAt)
+		}
+	}()
+
+	var pagesErr SearchPagesError
+	for i, page := range pages {
+		var result searchPage
+		select {
+		case result = <-page:
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+		<-slots
+
+		if result.err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			pagesErr = append(pagesErr, &SearchPageError{StartAt: starts[i], MaxResults: size, Err: result.err})
+			continue
+		}
+		for _, issue := range result.issues {
+			if err := fn(issue); err != nil {
+				return err
+			}
+		}
+	}
+
+	if len(pagesErr) > 0 {
+		return pagesErr
+	}
+	return nil
+}
+
+// SearchConcurrently wraps SearchConcurrentlyWithContext using the background context.
+func (s *IssueService) SearchConcurrently(jql string, options *SearchConcurrentOptions, fn func(Issue) error) error {
+	return s.SearchConcurrentlyWithContext(context.Background(), jql, options, fn)
+}
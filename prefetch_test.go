@@ -0,0 +1,167 @@
+package jira
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+)
+
+// testSearchHandler serves total issues with the keys TEST-0 ... TEST-(total-1).
+// Pages starting at an index contained in fail respond with status code 500.
+func testSearchHandler(t *testing.T, total int, fail map[int]bool, inFlight func(int)) http.HandlerFunc {
+	var mu sync.Mutex
+	current := 0
+	return func(w http.ResponseWriter, r *http.Request) {
+		mu.Lock()
+		current++
+		if inFlight != nil {
+			inFlight(current)
+		}
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			current--
+			mu.Unlock()
+		}()
+
+		startAt, _ := strconv.Atoi(r.URL.Query().Get("startAt"))
+		maxResults, _ := strconv.Atoi(r.URL.Query().Get("maxResults"))
+		if startAt > 0 {
+			// Give other workers the chance to run in parallel
+			time.Sleep(5 * time.Millisecond)
+		}
+		if fail[startAt] {
+			w.WriteHeader(http.StatusInternalServerError)
+			return
+		}
+
+		var issues []string
+		for i := startAt; i < startAt+maxResults && i < total; i++ {
+			issues = append(issues, fmt.Sprintf(`{"key":"TEST-%d"}`, i))
+		}
+		fmt.Fprintf(w, `{"startAt":%d,"maxResults":%d,"total":%d,"issues":[%s]}`, startAt, maxResults, total, strings.Join(issues, ","))
+	}
+}
+
+func TestIssueService_SearchConcurrently(t *testing.T) {
+	setup()
+	defer teardown()
+
+	maxInFlight := 0
+	testMux.HandleFunc("/rest/api/2/search", testSearchHandler(t, 11, nil, func(n int) {
+		if n > maxInFlight {
+			maxInFlight = n
+		}
+	}))
+
+	var keys []string
+	opt := &SearchConcurrentOptions{IssueSearchOptions: IssueSearchOptions{MaxResults: 2}, Workers: 3}
+	err := testClient.Issue.SearchConcurrently("type = Bug", opt, func(issue Issue) error {
+		keys = append(keys, issue.Key)
+		return nil
+	})
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	if len(keys) != 11 {
+		t.Fatalf("Expected 11 issues. Got %d", len(keys))
+	}
+	for i, key := range keys {
+		if want := fmt.Sprintf("TEST-%d", i); key != want {
+			t.Errorf("Expected issue %s at position %d. Got %s", want, i, key)
+		}
+	}
+	if maxInFlight > 3 {
+		t.Errorf("Expected at most 3 parallel requests. Got %d", maxInFlight)
+	}
+}
+
+func TestIssueService_SearchConcurrently_PageErrors(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", testSearchHandler(t, 10, map[int]bool{4: true, 8: true}, nil))
+
+	var keys []string
+	opt := &SearchConcurrentOptions{IssueSearchOptions: IssueSearchOptions{MaxResults: 2}}
+	err := testClient.Issue.SearchConcurrently("type = Bug", opt, func(issue Issue) error {
+		keys = append(keys, issue.Key)
+		return nil
+	})
+
+	var pagesErr SearchPagesError
+	if !errors.As(err, &pagesErr) {
+		t.Fatalf("Expected a SearchPagesError. Got %+v", err)
+	}
+	if len(pagesErr) != 2 || pagesErr[0].StartAt != 4 || pagesErr[1].StartAt != 8 {
+		t.Errorf("Expected failed pages starting at 4 and 8. Got %s", pagesErr)
+	}
+	var errorResponse *ErrorResponse
+	if !errors.As(pagesErr[0], &errorResponse) || errorResponse.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the page error to wrap the ErrorResponse. Got %+v", pagesErr[0].Err)
+	}
+	errorResponse = nil
+	if !errors.As(err, &errorResponse) || errorResponse.StatusCode != http.StatusInternalServerError {
+		t.Errorf("Expected the ErrorResponse to be found in the SearchPagesError. Got %+v", err)
+	}
+	if want := "TEST-0,TEST-1,TEST-2,TEST-3,TEST-6,TEST-7"; strings.Join(keys, ",") != want {
+		t.Errorf("Expected issues %s. Got %s", want, strings.Join(keys, ","))
+	}
+}
+
+func TestSearchPagesError_Is(t *testing.T) {
+	err := error(SearchPagesError{
+		{StartAt: 0, Err: errors.New("first")},
+		{StartAt: 50, Err: fmt.Errorf("second: %w", context.Canceled)},
+	})
+	if !errors.Is(err, context.Canceled) {
+		t.Errorf("Expected errors.Is to find the error of the second page. Got %+v", err)
+	}
+	if errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected errors.Is not to match an error of no page")
+	}
+}
+
+func TestIssueService_SearchConcurrently_CallbackError(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", testSearchHandler(t, 100, nil, nil))
+
+	stop := errors.New("stop")
+	count := 0
+	opt := &SearchConcurrentOptions{IssueSearchOptions: IssueSearchOptions{MaxResults: 5}}
+	err := testClient.Issue.SearchConcurrently("type = Bug", opt, func(issue Issue) error {
+		count++
+		if count == 7 {
+			return stop
+		}
+		return nil
+	})
+	if err != stop {
+		t.Errorf("Expected the error of the callback. Got %+v", err)
+	}
+	if count != 7 {
+		t.Errorf("Expected the callback to be called 7 times. Got %d", count)
+	}
+}
+
+func TestIssueService_SearchConcurrently_FirstPageError(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/search", testSearchHandler(t, 10, map[int]bool{0: true}, nil))
+
+	err := testClient.Issue.SearchConcurrently("type = Bug", nil, func(issue Issue) error {
+		t.Error("Unexpected issue")
+		return nil
+	})
+	var errorResponse *ErrorResponse
+	if !errors.As(err, &errorResponse) {
+		t.Errorf("Expected an ErrorResponse. Got %+v", err)
+	}
+}
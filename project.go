@@ -1,6 +1,7 @@
 package jira
 
 import (
+	"context"
 	"fmt"
 )
 
@@ -60,7 +61,7 @@ type Version struct {
 	Name            string `json:"name" structs:"name,omitempty"`
 	Archived        bool   `json:"archived" structs:"archived,omitempty"`
 	Released        bool   `json:"released" structs:"released,omitempty"`
-	ReleaseDate     string `json:"releaseDate" structs:"releaseDate,omitempty"`
+	ReleaseDate     *Date  `json:"releaseDate" structs:"releaseDate,omitempty,omitnested"`
 	UserReleaseDate string `json:"userReleaseDate" structs:"userReleaseDate,omitempty"`
 	ProjectID       int    `json:"projectId" structs:"projectId,omitempty"` // Unlike other IDs, this is returned as a number
 }
@@ -81,12 +82,12 @@ type ProjectComponent struct {
 	ProjectID           int    `json:"projectId" structs:"projectId,omitempty"`
 }
 
-// GetList gets all projects form JIRA
+// GetListWithContext gets all projects form JIRA
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/project-getAllProjects
-func (s *ProjectService) GetList() (*ProjectList, *Response, error) {
+func (s *ProjectService) GetListWithContext(ctx context.Context) (*ProjectList, *Response, error) {
 	apiEndpoint := "rest/api/2/project"
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -99,14 +100,19 @@ func (s *ProjectService) GetList() (*ProjectList, *Response, error) {
 	return projectList, resp, nil
 }
 
-// Get returns a full representation of the project for the given issue key.
+// GetList wraps GetListWithContext using the background context.
+func (s *ProjectService) GetList() (*ProjectList, *Response, error) {
+	return s.GetListWithContext(context.Background())
+}
+
+// GetWithContext returns a full representation of the project for the given issue key.
 // JIRA will attempt to identify the project by the projectIdOrKey path parameter.
 // This can be an project id, or an project key.
 //
 // JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/project-getProject
-func (s *ProjectService) Get(projectID string) (*Project, *Response, error) {
+func (s *ProjectService) GetWithContext(ctx context.Context, projectID string) (*Project, *Response, error) {
 	apiEndpoint := fmt.Sprintf("/rest/api/2/project/%s", projectID)
-	req, err := s.client.NewRequest("GET", apiEndpoint, nil)
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -118,3 +124,8 @@ func (s *ProjectService) Get(projectID string) (*Project, *Response, error) {
 	}
 	return project, resp, nil
 }
+
+// Get wraps GetWithContext using the background context.
+func (s *ProjectService) Get(projectID string) (*Project, *Response, error) {
+	return s.GetWithContext(context.Background(), projectID)
+}
@@ -0,0 +1,236 @@
+package jira
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"time"
+)
+
+// RetryPolicy configures if and how Client.Do retries requests that failed temporarily.
+// A request is retried if the HTTP round trip failed or JIRA answered with one of RetryStatusCodes.
+// Delays requested by JIRA via the "Retry-After" or "X-RateLimit-*" headers are honored.
+//
+// Request bodies created by NewRequest and NewMultiPartRequest are rewound for every attempt.
+// Requests with a body that can not be rewound are never retried.
+type RetryPolicy struct {
+	// MaxAttempts is the maximum number of attempts per request including the first one.
+	// Values lower than 2 disable retries.
+	MaxAttempts int
+
+	// MinBackoff is the delay before the first retry. It is doubled for every further retry.
+	MinBackoff time.Duration
+
+	// MaxBackoff caps the delay between two attempts, including delays requested by JIRA.
+	// Defaults to 30s.
+	MaxBackoff time.Duration
+
+	// Jitter is the fraction (0.0 - 1.0) of the computed backoff that is randomized,
+	// to avoid that many clients retry at the same time.
+	Jitter float64
+
+	// RetryStatusCodes are the HTTP status codes that are considered temporary.
+	// If empty, 429 (Too Many Requests), 502 (Bad Gateway), 503 (Service Unavailable)
+	// and 504 (Gateway Timeout) are retried.
+	RetryStatusCodes []int
+
+	// RetryNonIdempotent enables retries for non idempotent methods like POST and PATCH.
+	// By default only GET, HEAD, OPTIONS, TRACE, PUT and DELETE requests are retried.
+	// Searches sent as POST request because of a long JQL query are retried as well.
+	RetryNonIdempotent bool
+}
+
+// defaultRetryStatusCodes are the status codes retried if RetryPolicy.RetryStatusCodes is empty.
+var defaultRetryStatusCodes = []int{
+	http.StatusTooManyRequests,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// defaultMaxBackoff caps the delay between two attempts if RetryPolicy.MaxBackoff is not set.
+const defaultMaxBackoff = 30 * time.Second
+
+// NewRetryPolicy returns a RetryPolicy with sensible defaults:
+// up to 4 attempts, exponential backoff starting at 500ms capped at 30s and 20% jitter.
+func NewRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 4,
+		MinBackoff:  500 * time.Millisecond,
+		MaxBackoff:  defaultMaxBackoff,
+		Jitter:      0.2,
+	}
+}
+
+// SetRetryPolicy configures the retry behaviour of Do.
+// A nil policy disables retries, which is the default.
+func (c *Client) SetRetryPolicy(policy *RetryPolicy) {
+	c.retryPolicy = policy
+}
+
+// doWithRetry sends req and retries it according to the configured RetryPolicy.
+func (c *Client) doWithRetry(req *http.Request) (*http.Response, error) {
+	policy := c.retryPolicy
+	if policy == nil || policy.MaxAttempts < 2 || !policy.isRetryable(req) {
+		return c.client.Do(req)
+	}
+
+	ctx := req.Context()
+	for attempt := 1; ; attempt++ {
+		attemptReq := req
+		if attempt > 1 && req.Body != nil && req.Body != http.NoBody {
+			body, err := req.GetBody()
+			if err != nil {
+				return nil, err
+			}
+			attemptReq = req.Clone(ctx)
+			attemptReq.Body = body
+		}
+
+		resp, err := c.client.Do(attemptReq)
+		if attempt >= policy.MaxAttempts || ctx.Err() != nil || !policy.shouldRetry(resp, err) {
+			return resp, err
+		}
+
+		wait := policy.backoff(attempt, resp)
+		if resp != nil {
+			// Drain the body to be able to reuse the connection
+			io.Copy(ioutil.Discard, resp.Body)
+			resp.Body.Close()
+		}
+
+		timer := time.NewTimer(wait)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return nil, ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+// idempotentKey is the context key marking requests that are safe to retry
+// although their method is not idempotent, e.g. searches sent as POST request.
+type idempotentKey struct{}
+
+// markIdempotent returns a copy of req that is retried like a GET request.
+func markIdempotent(req *http.Request) *http.Request {
+	return req.WithContext(context.WithValue(req.Context(), idempotentKey{}, true))
+}
+
+// isRetryable reports if req is allowed to be sent more than once.
+func (p *RetryPolicy) isRetryable(req *http.Request) bool {
+	if req.Body != nil && req.Body != http.NoBody && req.GetBody == nil {
+		return false
+	}
+	if idempotent, _ := req.Context().Value(idempotentKey{}).(bool); idempotent {
+		return true
+	}
+
+	switch req.Method {
+	case "GET", "HEAD", "OPTIONS", "TRACE", "PUT", "DELETE":
+		return true
+	}
+	return p.RetryNonIdempotent
+}
+
+// shouldRetry reports if the outcome of an attempt is considered temporary.
+func (p *RetryPolicy) shouldRetry(resp *http.Response, err error) bool {
+	if err != nil {
+		return true
+	}
+
+	codes := p.RetryStatusCodes
+	if len(codes) == 0 {
+		codes = defaultRetryStatusCodes
+	}
+	for _, code := range codes {
+		if resp.StatusCode == code {
+			return true
+		}
+	}
+	return false
+}
+
+// backoff returns the delay before the next attempt.
+// A delay requested by JIRA takes precedence over the exponential backoff.
+func (p *RetryPolicy) backoff(attempt int, resp *http.Response) time.Duration {
+	maxBackoff := p.MaxBackoff
+	if maxBackoff <= 0 {
+		maxBackoff = defaultMaxBackoff
+	}
+
+	if wait, ok := retryAfter(resp, time.Now(), maxBackoff); ok {
+		return wait
+	}
+
+	wait := p.MinBackoff
+	// The loop stops at the cap, so the doubling can not overflow
+	for i := 1; i < attempt && wait < maxBackoff; i++ {
+		wait *= 2
+	}
+	if wait > maxBackoff {
+		wait = maxBackoff
+	}
+
+	if p.Jitter > 0 && wait > 0 {
+		jitter := p.Jitter
+		if jitter > 1 {
+			jitter = 1
+		}
+		spread := time.Duration(float64(wait) * jitter)
+		wait = wait - spread + time.Duration(rand.Int63n(int64(spread)+1))
+	}
+	return wait
+}
+
+// retryAfter extracts the delay requested by JIRA from the response headers, capped at maxBackoff.
+// The "Retry-After" header can be either a number of seconds or a HTTP date.
+// JIRA Cloud additionally announces the end of a rate limit window with "X-RateLimit-Reset"
+// once "X-RateLimit-Remaining" drops to zero.
+func retryAfter(resp *http.Response, now time.Time, maxBackoff time.Duration) (time.Duration, bool) {
+	if resp == nil {
+		return 0, false
+	}
+
+	if value := resp.Header.Get("Retry-After"); value != "" {
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil && seconds >= 0 {
+			// Compared before the conversion, huge values would overflow time.Duration
+			if seconds >= int64(maxBackoff/time.Second) {
+				return maxBackoff, true
+			}
+			return time.Duration(seconds) * time.Second, true
+		}
+		if date, err := http.ParseTime(value); err == nil {
+			return clamp(date.Sub(now), maxBackoff), true
+		}
+	}
+
+	if resp.Header.Get("X-RateLimit-Remaining") == "0" {
+		value := resp.Header.Get("X-RateLimit-Reset")
+		for _, layout := range []string{time.RFC3339, "2006-01-02T15:04Z07:00"} {
+			if reset, err := time.Parse(layout, value); err == nil {
+				return clamp(reset.Sub(now), maxBackoff), true
+			}
+		}
+		if seconds, err := strconv.ParseInt(value, 10, 64); err == nil {
+			return clamp(time.Unix(seconds, 0).Sub(now), maxBackoff), true
+		}
+	}
+
+	return 0, false
+}
+
+// clamp limits d to the range from 0 to max.
+func clamp(d, max time.Duration) time.Duration {
+	switch {
+	case d < 0:
+		return 0
+	case d > max:
+		return max
+	}
+	return d
+}
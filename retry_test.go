@@ -0,0 +1,202 @@
+package jira
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func testRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		MinBackoff:  time.Millisecond,
+		MaxBackoff:  10 * time.Millisecond,
+	}
+}
+
+func TestClient_Do_RetryTemporaryFailure(t *testing.T) {
+	setup()
+	defer teardown()
+	testClient.SetRetryPolicy(testRetryPolicy())
+
+	attempts := 0
+	testMux.HandleFunc("/rest/api/2/issue/10002", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		attempts++
+
+		body, _ := ioutil.ReadAll(r.Body)
+		if want := `{"key":"EX-1"}` + "\n"; string(body) != want {
+			t.Errorf("Attempt %d: Expected body %q. Got %q", attempts, want, body)
+		}
+
+		if attempts < 3 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req, _ := testClient.NewRequest("PUT", "rest/api/2/issue/10002", &Issue{Key: "EX-1"})
+	resp, err := testClient.Do(req, nil)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if resp.StatusCode != http.StatusNoContent {
+		t.Errorf("Expected status code %d. Got %d", http.StatusNoContent, resp.StatusCode)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts. Got %d", attempts)
+	}
+}
+
+func TestClient_Do_RetryMultiPartBody(t *testing.T) {
+	setup()
+	defer teardown()
+	policy := testRetryPolicy()
+	policy.RetryNonIdempotent = true
+	testClient.SetRetryPolicy(policy)
+
+	attempts := 0
+	testMux.HandleFunc("/rest/api/2/issue/10000/attachments", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		body, _ := ioutil.ReadAll(r.Body)
+		if string(body) != "file content" {
+			t.Errorf("Attempt %d: Expected body to be rewound. Got %q", attempts, body)
+		}
+		if attempts == 1 {
+			w.WriteHeader(http.StatusBadGateway)
+			return
+		}
+		fmt.Fprint(w, `[]`)
+	})
+
+	req, _ := testClient.NewMultiPartRequest("POST", "rest/api/2/issue/10000/attachments", bytes.NewBufferString("file content"))
+	_, err := testClient.Do(req, nil)
+	if err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+	if attempts != 2 {
+		t.Errorf("Expected 2 attempts. Got %d", attempts)
+	}
+}
+
+func TestClient_Do_NoRetryForNonIdempotentMethod(t *testing.T) {
+	setup()
+	defer teardown()
+	testClient.SetRetryPolicy(testRetryPolicy())
+
+	attempts := 0
+	testMux.HandleFunc("/rest/api/2/issue/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	req, _ := testClient.NewRequest("POST", "rest/api/2/issue/", &Issue{Key: "EX-1"})
+	_, err := testClient.Do(req, nil)
+	if err == nil {
+		t.Error("Expected an error. Got none")
+	}
+	if attempts != 1 {
+		t.Errorf("Expected 1 attempt. Got %d", attempts)
+	}
+}
+
+func TestClient_Do_RetryGivesUp(t *testing.T) {
+	setup()
+	defer teardown()
+	testClient.SetRetryPolicy(testRetryPolicy())
+
+	attempts := 0
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.Header().Set("Retry-After", "0")
+		w.WriteHeader(http.StatusTooManyRequests)
+	})
+
+	req, _ := testClient.NewRequest("GET", "/", nil)
+	_, err := testClient.Do(req, nil)
+	if !IsRateLimited(err) {
+		t.Errorf("Expected a rate limit error. Got %+v", err)
+	}
+	if attempts != 3 {
+		t.Errorf("Expected 3 attempts. Got %d", attempts)
+	}
+}
+
+func TestClient_Do_RetryContextCancelled(t *testing.T) {
+	setup()
+	defer teardown()
+	policy := testRetryPolicy()
+	policy.MinBackoff = time.Minute
+	policy.MaxBackoff = time.Minute
+	testClient.SetRetryPolicy(policy)
+
+	testMux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	})
+
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+
+	req, _ := testClient.NewRequestWithContext(ctx, "GET", "/", nil)
+	_, err := testClient.Do(req, nil)
+	if !errors.Is(err, context.DeadlineExceeded) {
+		t.Errorf("Expected context.DeadlineExceeded error. Got %+v", err)
+	}
+}
+
+func TestRetryPolicy_Backoff(t *testing.T) {
+	p := &RetryPolicy{MinBackoff: time.Second, MaxBackoff: 5 * time.Second}
+
+	expected := []time.Duration{time.Second, 2 * time.Second, 4 * time.Second, 5 * time.Second, 5 * time.Second}
+	for i, want := range expected {
+		if got := p.backoff(i+1, nil); got != want {
+			t.Errorf("Attempt %d: Expected backoff %s. Got %s", i+1, want, got)
+		}
+	}
+
+	p.Jitter = 0.5
+	for i := 0; i < 100; i++ {
+		if got := p.backoff(1, nil); got < 500*time.Millisecond || got > time.Second {
+			t.Errorf("Expected jittered backoff between 500ms and 1s. Got %s", got)
+		}
+	}
+
+	// Without MaxBackoff the delay is capped by default and does not overflow
+	p = &RetryPolicy{MinBackoff: time.Second}
+	for _, attempt := range []int{10, 64, 1000} {
+		if got := p.backoff(attempt, nil); got != defaultMaxBackoff {
+			t.Errorf("Attempt %d: Expected backoff %s. Got %s", attempt, defaultMaxBackoff, got)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	now := time.Date(2016, 3, 16, 4, 22, 0, 0, time.UTC)
+	tests := []struct {
+		header http.Header
+		want   time.Duration
+		ok     bool
+	}{
+		{http.Header{"Retry-After": {"7"}}, 7 * time.Second, true},
+		{http.Header{"Retry-After": {"120"}}, time.Minute, true},
+		{http.Header{"Retry-After": {"9223372036854775807"}}, time.Minute, true},
+		{http.Header{"Retry-After": {"Wed, 16 Mar 2017 04:22:30 GMT"}}, time.Minute, true},
+		{http.Header{"Retry-After": {"Wed, 16 Mar 2016 04:22:30 GMT"}}, 30 * time.Second, true},
+		{http.Header{"X-Ratelimit-Remaining": {"0"}, "X-Ratelimit-Reset": {"2016-03-16T04:23Z"}}, time.Minute, true},
+		{http.Header{"X-Ratelimit-Remaining": {"3"}, "X-Ratelimit-Reset": {"2016-03-16T04:23Z"}}, 0, false},
+		{http.Header{}, 0, false},
+	}
+
+	for _, test := range tests {
+		got, ok := retryAfter(&http.Response{Header: test.header}, now, time.Minute)
+		if got != test.want || ok != test.ok {
+			t.Errorf("Header %v: Expected (%s, %v). Got (%s, %v)", test.header, test.want, test.ok, got, ok)
+		}
+	}
+}
@@ -0,0 +1,176 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// greenHopperSprintPrefix starts the string representation of a sprint in the Sprint custom field of JIRA Server
+const greenHopperSprintPrefix = "com.atlassian.greenhopper.service.sprint.Sprint@"
+
+// greenHopperSprintAttribute matches the start of an attribute like ",name=" in the string representation of a sprint
+var greenHopperSprintAttribute = regexp.MustCompile(`(?:^|,)([a-zA-Z]+)=`)
+
+// ParseSprint parses the string representation of a sprint as found in the Sprint custom field of JIRA Server, e.g.
+//
+//	com.atlassian.greenhopper.service.sprint.Sprint@1a2b[id=42,rapidViewId=7,state=ACTIVE,name=Sprint 12,startDate=2017-01-02T10:00:00.000+01:00,...]
+//
+// The rapid view is the board the sprint was created on and returned as OriginBoardID.
+// The state is returned in lower case, like the agile API does.
+func ParseSprint(s string) (*Sprint, error) {
+	if !strings.HasPrefix(s, greenHopperSprintPrefix) {
+		return nil, fmt.Errorf("sprint %q: not a GreenHopper sprint", s)
+	}
+	start := strings.Index(s, "[")
+	if start < 0 || !strings.HasSuffix(s, "]") {
+		return nil, fmt.Errorf("sprint %q: missing attributes", s)
+	}
+	attributes := s[start+1 : len(s)-1]
+
+	sprint := new(Sprint)
+	matches := greenHopperSprintAttribute.FindAllStringSubmatchIndex(attributes, -1)
+	for i, m := range matches {
+		key := attributes[m[2]:m[3]]
+		end := len(attributes)
+		if i+1 < len(matches) {
+			end = matches[i+1][0]
+		}
+		value := attributes[m[1]:end]
+		if value == "<null>" {
+			continue
+		}
+
+		var err error
+		switch key {
+		case "id":
+			sprint.ID, err = strconv.Atoi(value)
+		case "rapidViewId":
+			sprint.OriginBoardID, err = strconv.Atoi(value)
+		case "state":
+			sprint.State = strings.ToLower(value)
+		case "name":
+			sprint.Name = value
+		case "goal":
+			sprint.Goal = value
+		case "startDate":
+			sprint.StartDate, err = parseSprintDate(value)
+		case "endDate":
+			sprint.EndDate, err = parseSprintDate(value)
+		case "completeDate":
+			sprint.CompleteDate, err = parseSprintDate(value)
+		}
+		if err != nil {
+			return nil, fmt.Errorf("sprint %q: %s: %w", s, key, err)
+		}
+	}
+	if sprint.ID == 0 {
+		return nil, fmt.Errorf("sprint %q: missing id", s)
+	}
+	return sprint, nil
+}
+
+func parseSprintDate(value string) (*time.Time, error) {
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// cloudSprint is a sprint as found in the Sprint custom field of JIRA Cloud
+type cloudSprint struct {
+	ID           int        `json:"id"`
+	Name         string     `json:"name"`
+	State        string     `json:"state"`
+	BoardID      int        `json:"boardId"`
+	Goal         string     `json:"goal"`
+	StartDate    *time.Time `json:"startDate"`
+	EndDate      *time.Time `json:"endDate"`
+	CompleteDate *time.Time `json:"completeDate"`
+}
+
+// ParseSprints parses the value of the Sprint custom field, as found in IssueFields.Unknowns.
+// JIRA Server sends a list of strings (see ParseSprint), JIRA Cloud a list of objects.
+// A nil value means that the issue was never part of a sprint.
+func ParseSprints(value interface{}) ([]Sprint, error) {
+	var values []interface{}
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case []interface{}:
+		values = v
+	case []string:
+		for _, s := range v {
+			values = append(values, s)
+		}
+	default:
+		values = []interface{}{v}
+	}
+
+	sprints := make([]Sprint, 0, len(values))
+	for _, v := range values {
+		if s, isString := v.(string); isString {
+			sprint, err := ParseSprint(s)
+			if err != nil {
+				return nil, err
+			}
+			sprints = append(sprints, *sprint)
+			continue
+		}
+
+		b, err := json.Marshal(v)
+		if err != nil {
+			return nil, err
+		}
+		var c cloudSprint
+		if err := json.Unmarshal(b, &c); err != nil {
+			return nil, fmt.Errorf("sprint %s: %w", b, err)
+		}
+		sprints = append(sprints, Sprint{
+			ID:            c.ID,
+			Name:          c.Name,
+			State:         strings.ToLower(c.State),
+			OriginBoardID: c.BoardID,
+			Goal:          c.Goal,
+			StartDate:     c.StartDate,
+			EndDate:       c.EndDate,
+			CompleteDate:  c.CompleteDate,
+		})
+	}
+	return sprints, nil
+}
+
+// Sprints returns all sprints the issue has been part of, read from the Sprint custom field sprintFieldID.
+// The id of the field differs between instances, use FieldRegistry.ID("Sprint") to look it up.
+func (i *Issue) Sprints(sprintFieldID string) ([]Sprint, error) {
+	if i.Fields == nil {
+		return nil, nil
+	}
+	return ParseSprints(i.Fields.Unknowns[sprintFieldID])
+}
+
+// CurrentSprint returns the sprint the issue is currently part of, read from the Sprint custom field sprintFieldID.
+// This is the active sprint or, if the issue is not part of an active sprint, a future one.
+// It returns nil if the issue is only part of closed sprints or of none at all.
+func (i *Issue) CurrentSprint(sprintFieldID string) (*Sprint, error) {
+	sprints, err := i.Sprints(sprintFieldID)
+	if err != nil {
+		return nil, err
+	}
+	var current *Sprint
+	for idx := range sprints {
+		switch sprints[idx].State {
+		case "active":
+			return &sprints[idx], nil
+		case "future":
+			if current == nil {
+				current = &sprints[idx]
+			}
+		}
+	}
+	return current, nil
+}
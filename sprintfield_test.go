@@ -0,0 +1,89 @@
+package jira
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestParseSprint(t *testing.T) {
+	s := "com.atlassian.greenhopper.service.sprint.Sprint@1a2b3c[id=42,rapidViewId=7,state=ACTIVE,name=Sprint 12, the one with commas,goal=<null>,startDate=2017-01-02T10:00:00.000+01:00,endDate=2017-01-16T10:00:00.000+01:00,completeDate=<null>,sequence=42]"
+	sprint, err := ParseSprint(s)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if sprint.ID != 42 || sprint.OriginBoardID != 7 || sprint.State != "active" || sprint.Name != "Sprint 12, the one with commas" || sprint.Goal != "" {
+		t.Errorf("Unexpected sprint %+v", sprint)
+	}
+	if sprint.StartDate == nil || !sprint.StartDate.Equal(time.Date(2017, 1, 2, 9, 0, 0, 0, time.UTC)) {
+		t.Errorf("Unexpected start date %v", sprint.StartDate)
+	}
+	if sprint.EndDate == nil || sprint.CompleteDate != nil {
+		t.Errorf("Expected an end date and no complete date, got %v and %v", sprint.EndDate, sprint.CompleteDate)
+	}
+}
+
+func TestParseSprint_Invalid(t *testing.T) {
+	for _, s := range []string{
+		"Sprint 12",
+		"com.atlassian.greenhopper.service.sprint.Sprint@1a2b3c",
+		"com.atlassian.greenhopper.service.sprint.Sprint@1a2b3c[id=abc,name=Sprint 12]",
+		"com.atlassian.greenhopper.service.sprint.Sprint@1a2b3c[name=Sprint 12]",
+		"com.atlassian.greenhopper.service.sprint.Sprint@1a2b3c[id=1,startDate=yesterday]",
+	} {
+		if _, err := ParseSprint(s); err == nil {
+			t.Errorf("Expected an error for %q", s)
+		}
+	}
+}
+
+func TestIssue_Sprints_Server(t *testing.T) {
+	var issue Issue
+	err := json.Unmarshal([]byte(`{"key": "EX-1", "fields": {"customfield_10005": [
+		"com.atlassian.greenhopper.service.sprint.Sprint@1a[id=41,rapidViewId=7,state=CLOSED,name=Sprint 11,startDate=2016-12-19T10:00:00.000+01:00,endDate=2017-01-02T10:00:00.000+01:00,completeDate=2017-01-02T11:00:00.000+01:00,sequence=41]",
+		"com.atlassian.greenhopper.service.sprint.Sprint@1b[id=42,rapidViewId=7,state=ACTIVE,name=Sprint 12,startDate=2017-01-02T10:00:00.000+01:00,endDate=2017-01-16T10:00:00.000+01:00,completeDate=<null>,sequence=42]"
+	]}}`), &issue)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	sprints, err := issue.Sprints("customfield_10005")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(sprints) != 2 || sprints[0].ID != 41 || sprints[0].CompleteDate == nil {
+		t.Errorf("Unexpected sprints %+v", sprints)
+	}
+	current, err := issue.CurrentSprint("customfield_10005")
+	if err != nil || current == nil || current.ID != 42 {
+		t.Errorf("Expected sprint 42 as current sprint, got %+v (%v)", current, err)
+	}
+}
+
+func TestIssue_Sprints_Cloud(t *testing.T) {
+	var issue Issue
+	err := json.Unmarshal([]byte(`{"key": "EX-1", "fields": {"customfield_10020": [
+		{"id": 41, "name": "Sprint 11", "state": "closed", "boardId": 7, "goal": "", "startDate": "2016-12-19T09:00:00.000Z", "endDate": "2017-01-02T09:00:00.000Z", "completeDate": "2017-01-02T10:00:00.000Z"},
+		{"id": 43, "name": "Sprint 13", "state": "future", "boardId": 7, "goal": "Ship it"}
+	]}}`), &issue)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	sprints, err := issue.Sprints("customfield_10020")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(sprints) != 2 || sprints[1].OriginBoardID != 7 || sprints[1].Goal != "Ship it" || sprints[1].StartDate != nil {
+		t.Errorf("Unexpected sprints %+v", sprints)
+	}
+	current, err := issue.CurrentSprint("customfield_10020")
+	if err != nil || current == nil || current.ID != 43 {
+		t.Errorf("Expected the future sprint 43 as current sprint, got %+v (%v)", current, err)
+	}
+
+	// Issues that never were in a sprint
+	if current, err := issue.CurrentSprint("customfield_99999"); err != nil || current != nil {
+		t.Errorf("Expected no current sprint, got %+v (%v)", current, err)
+	}
+}
@@ -0,0 +1,160 @@
+package timesheet
+
+import (
+	"encoding/csv"
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"strings"
+)
+
+// Format is an output format of a report.
+type Format string
+
+// The supported output formats
+const (
+	FormatCSV      Format = "csv"
+	FormatMarkdown Format = "markdown"
+	FormatJSON     Format = "json"
+)
+
+// Write renders the report to w in format.
+func (r *Report) Write(w io.Writer, format Format) error {
+	switch format {
+	case FormatCSV:
+		return r.WriteCSV(w)
+	case FormatMarkdown:
+		return r.WriteMarkdown(w)
+	case FormatJSON:
+		return r.WriteJSON(w)
+	}
+	return fmt.Errorf("timesheet: unknown format %q", format)
+}
+
+// WriteCSV renders the rows of the report as CSV with a header line.
+// The columns are the labels of the dimensions of the report followed by the hours.
+func (r *Report) WriteCSV(w io.Writer) error {
+	cw := csv.NewWriter(w)
+	header := make([]string, 0, len(r.GroupBy)+1)
+	for _, g := range r.GroupBy {
+		header = append(header, string(g))
+	}
+	if err := cw.Write(append(header, "hours")); err != nil {
+		return err
+	}
+	for _, row := range r.Rows {
+		record := append(append([]string{}, row.Labels...), formatHours(row.Seconds))
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
+// WriteMarkdown renders the rows of the report as Markdown table, followed by the total.
+func (r *Report) WriteMarkdown(w io.Writer) error {
+	header := make([]string, 0, len(r.GroupBy)+1)
+	for _, g := range r.GroupBy {
+		header = append(header, title(string(g)))
+	}
+	header = append(header, "Hours")
+
+	lines := []string{markdownRow(header)}
+	separator := make([]string, len(header))
+	for i := range separator {
+		separator[i] = "---"
+	}
+	separator[len(separator)-1] = "---:"
+	lines = append(lines, markdownRow(separator))
+
+	for _, row := range r.Rows {
+		lines = append(lines, markdownRow(append(append([]string{}, row.Labels...), formatHours(row.Seconds))))
+	}
+	total := make([]string, len(header))
+	total[0] = "**Total**"
+	total[len(total)-1] = "**" + formatHours(r.TotalSeconds) + "**"
+	lines = append(lines, markdownRow(total))
+
+	_, err := io.WriteString(w, strings.Join(lines, "\n")+"\n")
+	return err
+}
+
+// jsonReport is the JSON representation of a Report
+type jsonReport struct {
+	From         string                   `json:"from,omitempty"`
+	To           string                   `json:"to,omitempty"`
+	Timezone     string                   `json:"timezone"`
+	GroupBy      []GroupBy                `json:"groupBy"`
+	Rows         []map[string]interface{} `json:"rows"`
+	TotalSeconds int                      `json:"totalSeconds"`
+	TotalHours   float64                  `json:"totalHours"`
+}
+
+// WriteJSON renders the report as JSON object.
+// Each row is an object with the labels of the dimensions of the report as keys, plus "seconds" and "hours".
+// Rows grouped by user additionally contain the identity of the user as "userId".
+func (r *Report) WriteJSON(w io.Writer) error {
+	out := jsonReport{
+		GroupBy:      r.GroupBy,
+		Rows:         make([]map[string]interface{}, 0, len(r.Rows)),
+		TotalSeconds: r.TotalSeconds,
+		TotalHours:   r.TotalHours(),
+	}
+	if r.Location != nil {
+		out.Timezone = r.Location.String()
+	}
+	if !r.From.IsZero() {
+		out.From = r.From.Format(jsonTimeFormat)
+	}
+	if !r.To.IsZero() {
+		out.To = r.To.Format(jsonTimeFormat)
+	}
+	for _, row := range r.Rows {
+		values := map[string]interface{}{
+			"seconds": row.Seconds,
+			"hours":   row.Hours(),
+		}
+		for i, g := range r.GroupBy {
+			values[string(g)] = row.Labels[i]
+			if g == ByUser {
+				values["userId"] = row.Values[i]
+			}
+		}
+		out.Rows = append(out.Rows, values)
+	}
+
+	enc := json.NewEncoder(w)
+	enc.SetIndent("", "  ")
+	return enc.Encode(out)
+}
+
+// jsonTimeFormat is the format of From and To in the JSON output
+const jsonTimeFormat = "2006-01-02T15:04:05Z07:00"
+
+// hours converts seconds into hours, rounded to two decimals.
+func hours(seconds int) float64 {
+	return math.Round(float64(seconds)/36) / 100
+}
+
+func formatHours(seconds int) string {
+	return fmt.Sprintf("%.2f", hours(seconds))
+}
+
+// markdownRow renders cells as row of a Markdown table.
+func markdownRow(cells []string) string {
+	escaped := make([]string, len(cells))
+	for i, cell := range cells {
+		escaped[i] = strings.Replace(cell, "|", `\|`, -1)
+	}
+	return "| " + strings.Join(escaped, " | ") + " |"
+}
+
+// title returns s with an upper case first letter.
+func title(s string) string {
+	if s == "" {
+		return s
+	}
+	return strings.ToUpper(s[:1]) + s[1:]
+}
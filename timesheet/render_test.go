@@ -0,0 +1,105 @@
+package timesheet
+
+import (
+	"bytes"
+	"encoding/json"
+	"testing"
+	"time"
+)
+
+func TestReport_WriteCSV(t *testing.T) {
+	report := &Report{
+		GroupBy: []GroupBy{ByUser, ByProject},
+		Rows: []Row{
+			{Values: []string{"barney", "EX"}, Labels: []string{"Barney Rubble", "EX"}, Seconds: 7200},
+			{Values: []string{"barney", "OPS"}, Labels: []string{"Barney Rubble", "OPS"}, Seconds: 5400},
+			{Values: []string{"fred", "EX"}, Labels: []string{"Fred Flintstone", "EX"}, Seconds: 5400},
+		},
+		TotalSeconds: 18000,
+	}
+
+	var buf bytes.Buffer
+	if err := report.Write(&buf, FormatCSV); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := "user,project,hours\n" +
+		"Barney Rubble,EX,2.00\n" +
+		"Barney Rubble,OPS,1.50\n" +
+		"Fred Flintstone,EX,1.50\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected CSV\n%s. Got\n%s", want, got)
+	}
+}
+
+func TestReport_WriteMarkdown(t *testing.T) {
+	report := &Report{
+		GroupBy: []GroupBy{ByEpic},
+		Rows: []Row{
+			{Values: []string{""}, Labels: []string{""}, Seconds: 5400},
+			{Values: []string{"EX-10"}, Labels: []string{"EX-10"}, Seconds: 12600},
+		},
+		TotalSeconds: 18000,
+	}
+
+	var buf bytes.Buffer
+	if err := report.Write(&buf, FormatMarkdown); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := "| Epic | Hours |\n" +
+		"| --- | ---: |\n" +
+		"|  | 1.50 |\n" +
+		"| EX-10 | 3.50 |\n" +
+		"| **Total** | **5.00** |\n"
+	if got := buf.String(); got != want {
+		t.Errorf("Expected Markdown\n%s. Got\n%s", want, got)
+	}
+}
+
+func TestReport_WriteJSON(t *testing.T) {
+	report := &Report{
+		From:     time.Date(2016, 3, 7, 0, 0, 0, 0, time.UTC),
+		Location: time.UTC,
+		GroupBy:  []GroupBy{ByProject},
+		Rows: []Row{
+			{Values: []string{"EX"}, Labels: []string{"EX"}, Seconds: 12600},
+			{Values: []string{"OPS"}, Labels: []string{"OPS"}, Seconds: 5400},
+		},
+		TotalSeconds: 18000,
+	}
+
+	var buf bytes.Buffer
+	if err := report.Write(&buf, FormatJSON); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var got struct {
+		From       string
+		Timezone   string
+		GroupBy    []string
+		Rows       []map[string]interface{}
+		TotalHours float64
+	}
+	if err := json.Unmarshal(buf.Bytes(), &got); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got.From != "2016-03-07T00:00:00Z" || got.Timezone != "UTC" || got.TotalHours != 5 {
+		t.Errorf("Unexpected report %+v", got)
+	}
+	if len(got.Rows) != 2 || got.Rows[0]["project"] != "EX" || got.Rows[0]["seconds"] != float64(12600) || got.Rows[0]["hours"] != 3.5 {
+		t.Errorf("Unexpected rows %v", got.Rows)
+	}
+}
+
+func TestReport_WriteUnknownFormat(t *testing.T) {
+	report := &Report{}
+	if err := report.Write(&bytes.Buffer{}, "xlsx"); err == nil {
+		t.Error("Expected an error for an unknown format")
+	}
+}
+
+func TestHours(t *testing.T) {
+	for seconds, want := range map[int]float64{0: 0, 3600: 1, 5400: 1.5, 1000: 0.28} {
+		if got := hours(seconds); got != want {
+			t.Errorf("hours(%d): Expected %v. Got %v", seconds, want, got)
+		}
+	}
+}
@@ -0,0 +1,313 @@
+// Package timesheet aggregates the work logged on JIRA issues into timesheet reports,
+// e.g. the hours per person and project of the last week.
+//
+// The worklogs are attributed to the day they were started at in Config.Location,
+// so a worklog started late in the evening in New York counts for the same day there,
+// even if it is already the next day in UTC.
+//
+//	loc, _ := time.LoadLocation("Europe/Berlin")
+//	report, err := timesheet.Fetch(ctx, client, "project in (EX, OPS)", timesheet.Config{
+//		From:     time.Date(2016, 3, 7, 0, 0, 0, 0, loc),
+//		To:       time.Date(2016, 3, 14, 0, 0, 0, 0, loc),
+//		Location: loc,
+//		GroupBy:  []timesheet.GroupBy{timesheet.ByUser, timesheet.ByProject},
+//	})
+//	err = report.Write(os.Stdout, timesheet.FormatMarkdown)
+package timesheet
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+	"github.com/andygrunwald/go-jira/jql"
+)
+
+// GroupBy is a dimension the worklogs are aggregated by.
+type GroupBy string
+
+// The dimensions a report can be grouped by
+const (
+	// ByUser groups by the author of the worklog, see Entry.User
+	ByUser GroupBy = "user"
+	// ByIssue groups by the key of the issue
+	ByIssue GroupBy = "issue"
+	// ByEpic groups by the key of the epic of the issue, see Config.EpicLinkField
+	ByEpic GroupBy = "epic"
+	// ByProject groups by the key of the project of the issue
+	ByProject GroupBy = "project"
+	// ByDay groups by the day the work was started at, as YYYY-MM-DD in Config.Location
+	ByDay GroupBy = "day"
+)
+
+// dayFormat is the format of the days of ByDay
+const dayFormat = "2006-01-02"
+
+// Config controls which worklogs are part of a report and how they are aggregated.
+type Config struct {
+	// From and To limit the report to worklogs started at or after From and before To.
+	// A zero From or To leaves the range open on that side.
+	From time.Time
+	To   time.Time
+	// Location is the timezone the worklogs are attributed to days in. Defaults to time.Local.
+	Location *time.Location
+	// GroupBy are the dimensions of the rows of the report, in order. Defaults to ByUser.
+	GroupBy []GroupBy
+	// EpicLinkField is the id of the "Epic Link" custom field, e.g. "customfield_10005".
+	// It is used for ByEpic if the epic of an issue is not returned in its "epic" field.
+	EpicLinkField string
+}
+
+// Entry is a single worklog with the values of all dimensions.
+type Entry struct {
+	// User identifies the author by the key, or the login name if the key is not known.
+	// Unlike the display name, it is unique and stays the same for all worklogs of a person.
+	User string
+	// UserName is the display name of the author, it is empty if not known.
+	UserName string
+	Issue    string
+	Epic     string
+	Project  string
+	Day      string
+	Started  time.Time
+	Seconds  int
+}
+
+// value returns the value of the dimension g.
+func (e *Entry) value(g GroupBy) string {
+	switch g {
+	case ByUser:
+		return e.User
+	case ByIssue:
+		return e.Issue
+	case ByEpic:
+		return e.Epic
+	case ByProject:
+		return e.Project
+	case ByDay:
+		return e.Day
+	}
+	return ""
+}
+
+// Row is the time logged for one combination of the values of the dimensions of a report.
+type Row struct {
+	// Values are the values of the dimensions, in the order of Report.GroupBy.
+	// Issues without epic have an empty epic.
+	Values []string
+	// Labels are the values as shown in reports: the display name instead of the user, if known.
+	Labels  []string
+	Seconds int
+}
+
+// Hours returns the logged time in hours.
+func (r *Row) Hours() float64 {
+	return hours(r.Seconds)
+}
+
+// Report is an aggregated timesheet.
+type Report struct {
+	From     time.Time
+	To       time.Time
+	Location *time.Location
+	GroupBy  []GroupBy
+	// Rows are sorted by their labels.
+	Rows []Row
+	// Entries are all worklogs of the report, sorted by the time they were started at.
+	Entries      []Entry
+	TotalSeconds int
+}
+
+// TotalHours returns the time logged in total in hours.
+func (r *Report) TotalHours() float64 {
+	return hours(r.TotalSeconds)
+}
+
+// Fetch searches all issues matching jqlQuery with work logged between config.From and config.To
+// and computes the report of their worklogs.
+// Worklogs truncated by the search are fetched completely.
+func Fetch(ctx context.Context, client *jira.Client, jqlQuery string, config Config) (*Report, error) {
+	query, err := searchQuery(jqlQuery, config)
+	if err != nil {
+		return nil, fmt.Errorf("timesheet: %w", err)
+	}
+
+	fields := []string{"project", "worklog", "epic"}
+	if config.EpicLinkField != "" {
+		fields = append(fields, config.EpicLinkField)
+	}
+	it := client.Issue.SearchIteratorWithContext(ctx, query, &jira.IssueSearchOptions{Fields: fields})
+
+	var issues []jira.Issue
+	for it.Next() {
+		issue := it.Value()
+		if issue.Fields != nil && issue.Fields.Worklog != nil && len(issue.Fields.Worklog.Worklogs) < issue.Fields.Worklog.Total {
+			records, _, err := client.Issue.GetAllWorklogsWithContext(ctx, issue.Key)
+			if err != nil {
+				return nil, fmt.Errorf("timesheet: worklogs of %s: %w", issue.Key, err)
+			}
+			issue.Fields.Worklog = &jira.Worklog{Worklogs: records, MaxResults: len(records), Total: len(records)}
+		}
+		issues = append(issues, issue)
+	}
+	if err := it.Err(); err != nil {
+		return nil, fmt.Errorf("timesheet: %w", err)
+	}
+
+	return Compute(issues, config)
+}
+
+// searchQuery restricts jqlQuery to issues with work logged within the range of config.
+// JQL compares the dates in the timezone of the JIRA server, so the range is extended by a day on both sides.
+func searchQuery(jqlQuery string, config Config) (string, error) {
+	q, err := jql.Parse(jqlQuery)
+	if err != nil {
+		return "", err
+	}
+
+	var clauses []jql.Clause
+	if q.Where != nil {
+		clauses = append(clauses, q.Where)
+	}
+	if !config.From.IsZero() {
+		clauses = append(clauses, jql.Field("worklogDate").Gte(config.From.AddDate(0, 0, -1).Format(dayFormat)))
+	}
+	if !config.To.IsZero() {
+		clauses = append(clauses, jql.Field("worklogDate").Lte(config.To.AddDate(0, 0, 1).Format(dayFormat)))
+	}
+	switch len(clauses) {
+	case 0:
+	case 1:
+		q.Where = clauses[0]
+	default:
+		q.Where = jql.And(clauses...)
+	}
+	return q.String(), nil
+}
+
+// Compute aggregates the worklogs of issues.
+// The issues need the fields "project" and "worklog" including all worklogs,
+// and "epic" or Config.EpicLinkField if the report is grouped by epic.
+func Compute(issues []jira.Issue, config Config) (*Report, error) {
+	if config.Location == nil {
+		config.Location = time.Local
+	}
+	if len(config.GroupBy) == 0 {
+		config.GroupBy = []GroupBy{ByUser}
+	}
+	for _, g := range config.GroupBy {
+		switch g {
+		case ByUser, ByIssue, ByEpic, ByProject, ByDay:
+		default:
+			return nil, fmt.Errorf("timesheet: unknown group %q", g)
+		}
+	}
+
+	report := &Report{
+		From:     config.From,
+		To:       config.To,
+		Location: config.Location,
+		GroupBy:  config.GroupBy,
+	}
+	for _, issue := range issues {
+		if issue.Fields == nil || issue.Fields.Worklog == nil {
+			continue
+		}
+		epic := epicKey(issue, config.EpicLinkField)
+		for _, record := range issue.Fields.Worklog.Worklogs {
+			if record.Started == nil {
+				return nil, fmt.Errorf("timesheet: worklog %s of %s has no start time", record.ID, issue.Key)
+			}
+			started := time.Time(*record.Started)
+			if (!config.From.IsZero() && started.Before(config.From)) || (!config.To.IsZero() && !started.Before(config.To)) {
+				continue
+			}
+			report.Entries = append(report.Entries, Entry{
+				User:     userID(record.Author),
+				UserName: record.Author.DisplayName,
+				Issue:    issue.Key,
+				Epic:     epic,
+				Project:  issue.Fields.Project.Key,
+				Day:      started.In(config.Location).Format(dayFormat),
+				Started:  started,
+				Seconds:  record.TimeSpentSeconds,
+			})
+		}
+	}
+	sort.SliceStable(report.Entries, func(i, j int) bool {
+		return report.Entries[i].Started.Before(report.Entries[j].Started)
+	})
+
+	report.aggregate()
+	return report, nil
+}
+
+// aggregate sums the entries of the report up into its rows.
+func (r *Report) aggregate() {
+	// Worklogs may lack the display name of their author, any known one is used for all rows of the user
+	userNames := map[string]string{}
+	for _, e := range r.Entries {
+		if e.UserName != "" {
+			userNames[e.User] = e.UserName
+		}
+	}
+
+	index := map[string]int{}
+	for _, e := range r.Entries {
+		values := make([]string, 0, len(r.GroupBy))
+		for _, g := range r.GroupBy {
+			values = append(values, e.value(g))
+		}
+		key := fmt.Sprintf("%q", values)
+		i, ok := index[key]
+		if !ok {
+			i = len(r.Rows)
+			index[key] = i
+			labels := append([]string{}, values...)
+			for k, g := range r.GroupBy {
+				if name, ok := userNames[values[k]]; g == ByUser && ok {
+					labels[k] = name
+				}
+			}
+			r.Rows = append(r.Rows, Row{Values: values, Labels: labels})
+		}
+		r.Rows[i].Seconds += e.Seconds
+		r.TotalSeconds += e.Seconds
+	}
+
+	sort.Slice(r.Rows, func(i, j int) bool {
+		a, b := r.Rows[i], r.Rows[j]
+		for k := range a.Values {
+			if a.Labels[k] != b.Labels[k] {
+				return a.Labels[k] < b.Labels[k]
+			}
+			if a.Values[k] != b.Values[k] {
+				return a.Values[k] < b.Values[k]
+			}
+		}
+		return false
+	})
+}
+
+// epicKey returns the key of the epic of issue, or an empty string if it does not belong to an epic.
+func epicKey(issue jira.Issue, epicLinkField string) string {
+	if issue.Fields.Epic != nil && issue.Fields.Epic.Key != "" {
+		return issue.Fields.Epic.Key
+	}
+	if epicLinkField != "" {
+		if key, ok := issue.Fields.Unknowns[epicLinkField].(string); ok {
+			return key
+		}
+	}
+	return ""
+}
+
+// userID returns the stable identity of user: the key, or the login name if the key is not known.
+func userID(user jira.User) string {
+	if user.Key != "" {
+		return user.Key
+	}
+	return user.Name
+}
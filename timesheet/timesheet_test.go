@@ -0,0 +1,195 @@
+package timesheet
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	jira "github.com/andygrunwald/go-jira"
+)
+
+// testIssuesFile contains the worklogs of three issues:
+//   - EX-1 (epic EX-10): Fred late on March 7th in New York, Barney on March 8th
+//   - EX-2 (epic EX-10 by the Epic Link field): Fred on March 9th and 20th
+//   - OPS-1 (no epic): Barney on March 6th and 10th
+const testIssuesFile = "../mocks/timesheet_issues.json"
+
+func testConfig(groupBy ...GroupBy) Config {
+	return Config{
+		From:          time.Date(2016, 3, 7, 0, 0, 0, 0, time.UTC),
+		To:            time.Date(2016, 3, 14, 0, 0, 0, 0, time.UTC),
+		Location:      time.UTC,
+		GroupBy:       groupBy,
+		EpicLinkField: "customfield_10005",
+	}
+}
+
+func testStarted() *jira.Time {
+	started := jira.Time(time.Date(2016, 3, 8, 10, 0, 0, 0, time.UTC))
+	return &started
+}
+
+// rowsOf returns the rows of report as map of their joined values to their seconds.
+func rowsOf(report *Report) map[string]int {
+	rows := map[string]int{}
+	for _, row := range report.Rows {
+		rows[fmt.Sprint(row.Values)] = row.Seconds
+	}
+	return rows
+}
+
+func TestCompute_ByUser(t *testing.T) {
+	raw, err := ioutil.ReadFile(testIssuesFile)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	var issues []jira.Issue
+	if err := json.Unmarshal(raw, &issues); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	report, err := Compute(issues, testConfig())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	want := map[string]int{"[barney]": 12600, "[fred]": 5400}
+	if got := rowsOf(report); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected rows %v. Got %v", want, got)
+	}
+	if report.TotalSeconds != 18000 || report.TotalHours() != 5 {
+		t.Errorf("Expected 5 hours in total. Got %d seconds", report.TotalSeconds)
+	}
+	if len(report.Entries) != 4 || report.Entries[0].Issue != "EX-1" || report.Entries[3].Issue != "OPS-1" {
+		t.Errorf("Unexpected entries %+v", report.Entries)
+	}
+	if report.Rows[0].Values[0] != "barney" || report.Rows[0].Labels[0] != "Barney Rubble" || report.Rows[1].Labels[0] != "Fred Flintstone" {
+		t.Errorf("Expected the rows to be sorted and labeled with the display names. Got %v", report.Rows)
+	}
+}
+
+func TestCompute_ByUserSameDisplayName(t *testing.T) {
+	issues := []jira.Issue{{Key: "EX-1", Fields: &jira.IssueFields{Project: jira.Project{Key: "EX"}, Worklog: &jira.Worklog{Worklogs: []jira.WorklogRecord{
+		{ID: "1", Author: jira.User{Name: "jsmith", Key: "JIRAUSER10001", DisplayName: "John Smith"}, Started: testStarted(), TimeSpentSeconds: 3600},
+		{ID: "2", Author: jira.User{Name: "jsmith2", Key: "JIRAUSER10002", DisplayName: "John Smith"}, Started: testStarted(), TimeSpentSeconds: 1800},
+	}}}}}
+	report, err := Compute(issues, testConfig())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := map[string]int{"[JIRAUSER10001]": 3600, "[JIRAUSER10002]": 1800}
+	if got := rowsOf(report); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected a row per person. Got %v", got)
+	}
+}
+
+func TestCompute_ByEpicAndProject(t *testing.T) {
+	raw, err := ioutil.ReadFile(testIssuesFile)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	var issues []jira.Issue
+	if err := json.Unmarshal(raw, &issues); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	report, err := Compute(issues, testConfig(ByEpic, ByProject))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	want := map[string]int{"[EX-10 EX]": 12600, "[ OPS]": 5400}
+	if got := rowsOf(report); fmt.Sprint(got) != fmt.Sprint(want) {
+		t.Errorf("Expected rows %v. Got %v", want, got)
+	}
+
+	report, err = Compute(issues, testConfig(ByIssue))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got := rowsOf(report)["[EX-2]"]; got != 1800 {
+		t.Errorf("Expected the worklog after the range to be ignored. Got %d seconds", got)
+	}
+}
+
+func TestCompute_ByDayTimezone(t *testing.T) {
+	raw, err := ioutil.ReadFile(testIssuesFile)
+	if err != nil {
+		t.Error(err.Error())
+	}
+	var issues []jira.Issue
+	if err := json.Unmarshal(raw, &issues); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	report, err := Compute(issues, testConfig(ByDay))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got := rowsOf(report); got["[2016-03-08]"] != 10800 || got["[2016-03-07]"] != 0 {
+		t.Errorf("Expected all work of March 8th in UTC. Got %v", got)
+	}
+
+	newYork := time.FixedZone("EST", -5*60*60)
+	config := testConfig(ByDay)
+	config.Location = newYork
+	report, err = Compute(issues, config)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if got := rowsOf(report); got["[2016-03-07]"] != 3600 || got["[2016-03-08]"] != 7200 {
+		t.Errorf("Expected Fred's work on March 7th in New York. Got %v", got)
+	}
+}
+
+func TestCompute_UnknownGroup(t *testing.T) {
+	if _, err := Compute(nil, Config{GroupBy: []GroupBy{"team"}}); err == nil {
+		t.Error("Expected an error for an unknown group")
+	}
+}
+
+func TestSearchQuery(t *testing.T) {
+	q, err := searchQuery("project = EX ORDER BY key", testConfig())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := `project = "EX" AND worklogDate >= "2016-03-06" AND worklogDate <= "2016-03-15" ORDER BY key`; q != want {
+		t.Errorf("Expected %s. Got %s", want, q)
+	}
+
+	if q, _ := searchQuery("", Config{}); q != "" {
+		t.Errorf("Expected an empty query. Got %s", q)
+	}
+}
+
+func TestFetch(t *testing.T) {
+	mux := http.NewServeMux()
+	server := httptest.NewServer(mux)
+	defer server.Close()
+	mux.HandleFunc("/rest/api/2/search", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"startAt": 0, "maxResults": 50, "total": 1, "issues": [
+			{"key":"EX-1","fields":{"project":{"key":"EX"},"worklog":{"startAt":0,"maxResults":1,"total":2,"worklogs":[
+				{"id":"1","author":{"name":"fred"},"started":"2016-03-08T10:00:00.000+0000","timeSpentSeconds":3600}
+			]}}}
+		]}`)
+	})
+	mux.HandleFunc("/rest/api/2/issue/EX-1/worklog", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"startAt": 0, "maxResults": 2, "total": 2, "worklogs": [
+			{"id":"1","author":{"name":"fred"},"started":"2016-03-08T10:00:00.000+0000","timeSpentSeconds":3600},
+			{"id":"2","author":{"name":"fred"},"started":"2016-03-09T10:00:00.000+0000","timeSpentSeconds":1800}
+		]}`)
+	})
+
+	client, err := jira.NewClient(nil, server.URL)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	report, err := Fetch(context.Background(), client, "project = EX", testConfig())
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if report.TotalSeconds != 5400 {
+		t.Errorf("Expected the truncated worklogs to be fetched. Got %d seconds", report.TotalSeconds)
+	}
+}
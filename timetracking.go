@@ -0,0 +1,209 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// TimeTracking represents the time tracking of an issue.
+// The estimates are given as JIRA duration strings like "1w 2d 3h 30m", see TimeTrackingConfiguration.ParseDuration.
+// When an issue is created or updated, only OriginalEstimate and RemainingEstimate are used.
+type TimeTracking struct {
+	OriginalEstimate         string `json:"originalEstimate,omitempty" structs:"originalEstimate,omitempty"`
+	RemainingEstimate        string `json:"remainingEstimate,omitempty" structs:"remainingEstimate,omitempty"`
+	TimeSpent                string `json:"timeSpent,omitempty" structs:"timeSpent,omitempty"`
+	OriginalEstimateSeconds  int    `json:"originalEstimateSeconds,omitempty" structs:"originalEstimateSeconds,omitempty"`
+	RemainingEstimateSeconds int    `json:"remainingEstimateSeconds,omitempty" structs:"remainingEstimateSeconds,omitempty"`
+	TimeSpentSeconds         int    `json:"timeSpentSeconds,omitempty" structs:"timeSpentSeconds,omitempty"`
+}
+
+// estimates returns the estimates that can be set in create and update requests.
+func (t *TimeTracking) estimates() map[string]string {
+	estimates := map[string]string{}
+	if t.OriginalEstimate != "" {
+		estimates["originalEstimate"] = t.OriginalEstimate
+	}
+	if t.RemainingEstimate != "" {
+		estimates["remainingEstimate"] = t.RemainingEstimate
+	}
+	return estimates
+}
+
+// OriginalEstimateDuration returns the original estimate as time.Duration.
+func (t *TimeTracking) OriginalEstimateDuration() time.Duration {
+	return time.Duration(t.OriginalEstimateSeconds) * time.Second
+}
+
+// RemainingEstimateDuration returns the remaining estimate as time.Duration.
+func (t *TimeTracking) RemainingEstimateDuration() time.Duration {
+	return time.Duration(t.RemainingEstimateSeconds) * time.Second
+}
+
+// TimeSpentDuration returns the logged time as time.Duration.
+func (t *TimeTracking) TimeSpentDuration() time.Duration {
+	return time.Duration(t.TimeSpentSeconds) * time.Second
+}
+
+// SetTimeTracking sets the original and the remaining estimate of an issue, e.g. "2d" and "1d 4h".
+// An empty estimate is not changed.
+func (r *UpdateIssueRequest) SetTimeTracking(originalEstimate, remainingEstimate string) *UpdateIssueRequest {
+	timeTracking := &TimeTracking{OriginalEstimate: originalEstimate, RemainingEstimate: remainingEstimate}
+	return r.Edit("timetracking", timeTracking.estimates())
+}
+
+// TimeTrackingConfiguration is the time tracking configuration of a JIRA instance.
+// The length of a day and a week in durations like "1w 2d" depends on it.
+type TimeTrackingConfiguration struct {
+	WorkingHoursPerDay float64 `json:"workingHoursPerDay" structs:"workingHoursPerDay"`
+	WorkingDaysPerWeek float64 `json:"workingDaysPerWeek" structs:"workingDaysPerWeek"`
+	// TimeFormat is "pretty", "days" or "hours"
+	TimeFormat string `json:"timeFormat" structs:"timeFormat"`
+	// DefaultUnit is the unit of durations without unit: "minute", "hour", "day" or "week"
+	DefaultUnit string `json:"defaultUnit" structs:"defaultUnit"`
+}
+
+// DefaultTimeTrackingConfiguration is the time tracking configuration of JIRA after its installation:
+// 8 hours per day, 5 days per week and minutes as default unit.
+var DefaultTimeTrackingConfiguration = TimeTrackingConfiguration{
+	WorkingHoursPerDay: 8,
+	WorkingDaysPerWeek: 5,
+	TimeFormat:         "pretty",
+	DefaultUnit:        "minute",
+}
+
+// configuration is the response of the configuration endpoint
+type configuration struct {
+	TimeTrackingEnabled       bool                       `json:"timeTrackingEnabled"`
+	TimeTrackingConfiguration *TimeTrackingConfiguration `json:"timeTrackingConfiguration"`
+}
+
+// GetTimeTrackingConfigurationWithContext returns the time tracking configuration of the JIRA instance.
+// If time tracking is disabled, an error is returned.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/configuration-getConfiguration
+func (s *IssueService) GetTimeTrackingConfigurationWithContext(ctx context.Context) (*TimeTrackingConfiguration, *Response, error) {
+	apiEndpoint := "rest/api/2/configuration"
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	config := new(configuration)
+	resp, err := s.client.Do(req, config)
+	if err != nil {
+		return nil, resp, err
+	}
+	if !config.TimeTrackingEnabled || config.TimeTrackingConfiguration == nil {
+		return nil, resp, fmt.Errorf("time tracking is disabled")
+	}
+	return config.TimeTrackingConfiguration, resp, nil
+}
+
+// GetTimeTrackingConfiguration wraps GetTimeTrackingConfigurationWithContext using the background context.
+func (s *IssueService) GetTimeTrackingConfiguration() (*TimeTrackingConfiguration, *Response, error) {
+	return s.GetTimeTrackingConfigurationWithContext(context.Background())
+}
+
+// units returns the length of the duration units of JIRA, largest first.
+func (c *TimeTrackingConfiguration) units() []struct {
+	name   string
+	length time.Duration
+} {
+	hoursPerDay, daysPerWeek := c.WorkingHoursPerDay, c.WorkingDaysPerWeek
+	if hoursPerDay <= 0 {
+		hoursPerDay = DefaultTimeTrackingConfiguration.WorkingHoursPerDay
+	}
+	if daysPerWeek <= 0 {
+		daysPerWeek = DefaultTimeTrackingConfiguration.WorkingDaysPerWeek
+	}
+	day := time.Duration(hoursPerDay * float64(time.Hour))
+	return []struct {
+		name   string
+		length time.Duration
+	}{
+		{"w", time.Duration(daysPerWeek * float64(day))},
+		{"d", day},
+		{"h", time.Hour},
+		{"m", time.Minute},
+	}
+}
+
+// ParseDuration parses a JIRA duration string like "1w 2d 3h 30m" or "1.5h".
+// Days and weeks are working days and weeks as configured by c. A number without unit is in the DefaultUnit.
+func (c *TimeTrackingConfiguration) ParseDuration(s string) (time.Duration, error) {
+	units := c.units()
+	fields := strings.Fields(strings.ToLower(s))
+	if len(fields) == 0 {
+		return 0, fmt.Errorf("invalid duration %q", s)
+	}
+
+	var d time.Duration
+	for _, field := range fields {
+		number, unit := field, ""
+		if last := field[len(field)-1]; last < '0' || last > '9' {
+			number, unit = field[:len(field)-1], field[len(field)-1:]
+		} else {
+			unit = c.defaultUnit()
+		}
+
+		value, err := strconv.ParseFloat(number, 64)
+		if err != nil || value < 0 {
+			return 0, fmt.Errorf("invalid duration %q", s)
+		}
+		found := false
+		for _, u := range units {
+			if u.name == unit {
+				d += time.Duration(math.Round(value * float64(u.length)))
+				found = true
+				break
+			}
+		}
+		if !found {
+			return 0, fmt.Errorf("invalid duration %q: unknown unit %q", s, unit)
+		}
+	}
+	return d, nil
+}
+
+// defaultUnit returns the abbreviation of DefaultUnit.
+func (c *TimeTrackingConfiguration) defaultUnit() string {
+	switch c.DefaultUnit {
+	case "week":
+		return "w"
+	case "day":
+		return "d"
+	case "hour":
+		return "h"
+	}
+	return "m"
+}
+
+// FormatDuration formats d as JIRA duration string like "1w 2d 3h 30m".
+// Days and weeks are working days and weeks as configured by c. d is truncated to full minutes.
+func (c *TimeTrackingConfiguration) FormatDuration(d time.Duration) string {
+	if d < time.Minute {
+		return "0m"
+	}
+	var parts []string
+	for _, u := range c.units() {
+		if n := d / u.length; n > 0 {
+			parts = append(parts, fmt.Sprintf("%d%s", n, u.name))
+			d -= n * u.length
+		}
+	}
+	return strings.Join(parts, " ")
+}
+
+// ParseDuration parses a JIRA duration string with the DefaultTimeTrackingConfiguration.
+func ParseDuration(s string) (time.Duration, error) {
+	return DefaultTimeTrackingConfiguration.ParseDuration(s)
+}
+
+// FormatDuration formats d as JIRA duration string with the DefaultTimeTrackingConfiguration.
+func FormatDuration(d time.Duration) string {
+	return DefaultTimeTrackingConfiguration.FormatDuration(d)
+}
@@ -0,0 +1,187 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestParseDuration(t *testing.T) {
+	for s, want := range map[string]time.Duration{
+		"1w 2d 3h 30m": 40*time.Hour + 16*time.Hour + 3*time.Hour + 30*time.Minute,
+		"1.5h":         90 * time.Minute,
+		"2D 4H":        20 * time.Hour,
+		"45":           45 * time.Minute,
+		"0m":           0,
+	} {
+		got, err := ParseDuration(s)
+		if err != nil {
+			t.Errorf("ParseDuration(%q): Error given: %s", s, err)
+		} else if got != want {
+			t.Errorf("ParseDuration(%q): Expected %s, recieved %s", s, want, got)
+		}
+	}
+
+	for _, s := range []string{"", "h", "3x", "-1h", "1h foo"} {
+		if _, err := ParseDuration(s); err == nil {
+			t.Errorf("ParseDuration(%q): Expected an error", s)
+		}
+	}
+}
+
+func TestFormatDuration(t *testing.T) {
+	for d, want := range map[time.Duration]string{
+		0:                             "0m",
+		30 * time.Second:              "0m",
+		90 * time.Minute:              "1h 30m",
+		59*time.Hour + 30*time.Minute: "1w 2d 3h 30m",
+		80 * time.Hour:                "2w",
+		8*time.Hour + time.Minute + 59*time.Second: "1d 1m",
+	} {
+		if got := FormatDuration(d); got != want {
+			t.Errorf("FormatDuration(%s): Expected %s, recieved %s", d, want, got)
+		}
+	}
+}
+
+func TestTimeTrackingConfiguration_CustomDays(t *testing.T) {
+	config := &TimeTrackingConfiguration{WorkingHoursPerDay: 7.5, WorkingDaysPerWeek: 4, DefaultUnit: "hour"}
+
+	d, err := config.ParseDuration("1w 1d 2")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if want := 30*time.Hour + 7*time.Hour + 30*time.Minute + 2*time.Hour; d != want {
+		t.Errorf("Expected %s, recieved %s", want, d)
+	}
+	if got, want := config.FormatDuration(d), "1w 1d 2h"; got != want {
+		t.Errorf("Expected %s, recieved %s", want, got)
+	}
+}
+
+func TestIssueService_GetTimeTrackingConfiguration(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/configuration", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/configuration")
+		fmt.Fprint(w, `{"votingEnabled": true, "timeTrackingEnabled": true, "timeTrackingConfiguration": {"workingHoursPerDay": 6.0, "workingDaysPerWeek": 4.5, "timeFormat": "pretty", "defaultUnit": "hour"}}`)
+	})
+
+	config, _, err := testClient.Issue.GetTimeTrackingConfiguration()
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if config.WorkingHoursPerDay != 6 || config.WorkingDaysPerWeek != 4.5 || config.DefaultUnit != "hour" {
+		t.Errorf("Unexpected configuration %+v", config)
+	}
+}
+
+func TestIssueService_GetTimeTrackingConfiguration_Disabled(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/configuration", func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, `{"timeTrackingEnabled": false}`)
+	})
+
+	if _, _, err := testClient.Issue.GetTimeTrackingConfiguration(); err == nil {
+		t.Error("Expected an error if time tracking is disabled")
+	}
+}
+
+func TestIssueFields_TimeTracking(t *testing.T) {
+	data := `{
+		"timespent": 12600,
+		"timeestimate": 16200,
+		"timeoriginalestimate": 28800,
+		"aggregatetimespent": 12600,
+		"aggregatetimeestimate": null,
+		"workratio": 43,
+		"timetracking": {
+			"originalEstimate": "1d",
+			"remainingEstimate": "4h 30m",
+			"timeSpent": "3h 30m",
+			"originalEstimateSeconds": 28800,
+			"remainingEstimateSeconds": 16200,
+			"timeSpentSeconds": 12600
+		}
+	}`
+	fields := new(IssueFields)
+	if err := json.Unmarshal([]byte(data), fields); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+
+	if fields.TimeSpent == nil || *fields.TimeSpent != 12600 || fields.TimeOriginalEstimate == nil || *fields.TimeOriginalEstimate != 28800 {
+		t.Errorf("Unexpected time values %v, %v", fields.TimeSpent, fields.TimeOriginalEstimate)
+	}
+	if fields.AggregateTimeEstimate != nil {
+		t.Errorf("Expected nil for a null value, recieved %d", *fields.AggregateTimeEstimate)
+	}
+	if fields.WorkRatio == nil || *fields.WorkRatio != 43 {
+		t.Errorf("Expected work ratio 43, recieved %v", fields.WorkRatio)
+	}
+	if _, ok := fields.Unknowns["timespent"]; ok {
+		t.Error("Expected timespent not to be an unknown field")
+	}
+
+	tt := fields.TimeTracking
+	if tt == nil || tt.RemainingEstimate != "4h 30m" || tt.RemainingEstimateDuration() != 270*time.Minute || tt.TimeSpentDuration() != 210*time.Minute {
+		t.Errorf("Unexpected time tracking %+v", tt)
+	}
+}
+
+func TestIssueFields_MarshalJSON_OmitsReadOnlyTimeFields(t *testing.T) {
+	spent, ratio := 12600, 0
+	fields := &IssueFields{
+		Summary:      "Example",
+		TimeSpent:    &spent,
+		WorkRatio:    &ratio,
+		TimeTracking: &TimeTracking{OriginalEstimate: "1d", TimeSpent: "3h 30m", TimeSpentSeconds: 12600},
+	}
+	b, err := json.Marshal(fields)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	var m map[string]interface{}
+	if err := json.Unmarshal(b, &m); err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if _, ok := m["timespent"]; ok {
+		t.Errorf("Expected timespent not to be sent, recieved %s", b)
+	}
+	if _, ok := m["workratio"]; ok {
+		t.Errorf("Expected workratio not to be sent, recieved %s", b)
+	}
+	if got, _ := json.Marshal(m["timetracking"]); string(got) != `{"originalEstimate":"1d"}` {
+		t.Errorf("Expected only the estimates of the time tracking, recieved %s", got)
+	}
+
+	// Without estimates the time tracking is left out completely
+	fields.TimeTracking = &TimeTracking{TimeSpentSeconds: 12600}
+	if b, _ = json.Marshal(fields); strings.Contains(string(b), "timetracking") {
+		t.Errorf("Expected timetracking not to be sent, recieved %s", b)
+	}
+}
+
+func TestUpdateIssueRequest_SetTimeTracking(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/PROJ-9001", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		b, _ := ioutil.ReadAll(r.Body)
+		want := `{"update":{"timetracking":[{"edit":{"remainingEstimate":"1d 4h"}}]}}`
+		if got := string(b); got != want+"\n" {
+			t.Errorf("Expected body %s, recieved %s", want, got)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	req := (&UpdateIssueRequest{}).SetTimeTracking("", "1d 4h")
+	if _, err := testClient.Issue.UpdateIssue("PROJ-9001", req); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
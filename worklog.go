@@ -0,0 +1,397 @@
+package jira
+
+import (
+	"context"
+	"fmt"
+	"time"
+)
+
+// The adjustEstimate modes define how adding, updating or deleting a worklog changes the remaining estimate of an issue.
+const (
+	// AdjustEstimateAuto reduces (or increases on delete) the remaining estimate by the time spent. This is the default.
+	AdjustEstimateAuto = "auto"
+	// AdjustEstimateNew sets the remaining estimate to WorklogOptions.NewEstimate.
+	AdjustEstimateNew = "new"
+	// AdjustEstimateLeave keeps the remaining estimate unchanged.
+	AdjustEstimateLeave = "leave"
+	// AdjustEstimateManual reduces the remaining estimate by WorklogOptions.ReduceBy when a worklog is added,
+	// or increases it by WorklogOptions.IncreaseBy when a worklog is deleted.
+	AdjustEstimateManual = "manual"
+)
+
+// worklogListLimit is the maximum number of worklogs that can be requested at once from the worklog/list endpoint.
+const worklogListLimit = 1000
+
+// WorklogOptions specifies how adding, updating or deleting a worklog adjusts the remaining estimate of the issue.
+// The estimates are JIRA duration strings like "1d 4h".
+type WorklogOptions struct {
+	// AdjustEstimate: One of the AdjustEstimate* modes. Default: AdjustEstimateAuto.
+	AdjustEstimate string `url:"adjustEstimate,omitempty"`
+	// NewEstimate: The new remaining estimate, required by AdjustEstimateNew.
+	NewEstimate string `url:"newEstimate,omitempty"`
+	// ReduceBy: The time to subtract from the remaining estimate, required by AdjustEstimateManual when a worklog is added.
+	ReduceBy string `url:"reduceBy,omitempty"`
+	// IncreaseBy: The time to add to the remaining estimate, required by AdjustEstimateManual when a worklog is deleted.
+	IncreaseBy string `url:"increaseBy,omitempty"`
+}
+
+// GetWorklogsOptions specifies the optional parameters to GetWorklogs
+type GetWorklogsOptions struct {
+	// StartAt: The starting index of the returned worklogs. Base index: 0.
+	StartAt int `url:"startAt,omitempty"`
+	// MaxResults: The maximum number of worklogs to return per page.
+	MaxResults int `url:"maxResults,omitempty"`
+	// StartedAfter: Only worklogs started after this time are returned, in milliseconds since the epoch.
+	StartedAfter int64 `url:"startedAfter,omitempty"`
+}
+
+// WorklogChange represents a worklog that was updated or deleted.
+type WorklogChange struct {
+	WorklogID int `json:"worklogId" structs:"worklogId"`
+	// UpdatedTime is the time of the change in milliseconds since the epoch.
+	UpdatedTime int64 `json:"updatedTime" structs:"updatedTime"`
+}
+
+// WorklogChanges represents one page of the worklogs updated or deleted since a point in time.
+// The next page starts at Until, see GetUpdatedWorklogs.
+type WorklogChanges struct {
+	Values   []WorklogChange `json:"values" structs:"values"`
+	Since    int64           `json:"since" structs:"since"`
+	Until    int64           `json:"until" structs:"until"`
+	Self     string          `json:"self" structs:"self"`
+	NextPage string          `json:"nextPage,omitempty" structs:"nextPage,omitempty"`
+	LastPage bool            `json:"lastPage" structs:"lastPage"`
+}
+
+// WorklogSync is the result of SyncWorklogs.
+type WorklogSync struct {
+	// Updated contains all worklogs created or updated since the checkpoint.
+	Updated []WorklogRecord
+	// Deleted contains the ids of all worklogs deleted since the checkpoint.
+	Deleted []int
+	// Until is the checkpoint of the next sync.
+	Until time.Time
+}
+
+// worklogRequest is the body of a request to add or update a worklog.
+// Only the fields that can be changed are sent.
+type worklogRequest struct {
+	Comment          string             `json:"comment,omitempty"`
+	Started          *Time              `json:"started,omitempty"`
+	TimeSpent        string             `json:"timeSpent,omitempty"`
+	TimeSpentSeconds int                `json:"timeSpentSeconds,omitempty"`
+	Visibility       *CommentVisibility `json:"visibility,omitempty"`
+}
+
+func newWorklogRequest(record *WorklogRecord) *worklogRequest {
+	return &worklogRequest{
+		Comment:          record.Comment,
+		Started:          record.Started,
+		TimeSpent:        record.TimeSpent,
+		TimeSpentSeconds: record.TimeSpentSeconds,
+		Visibility:       record.Visibility,
+	}
+}
+
+// validate checks that options contain the estimate required by its adjustEstimate mode.
+// method is the HTTP method of the request, as not all modes are supported for all requests.
+func (o *WorklogOptions) validate(method string) error {
+	if o == nil {
+		return nil
+	}
+	switch o.AdjustEstimate {
+	case "", AdjustEstimateAuto, AdjustEstimateLeave:
+		return nil
+	case AdjustEstimateNew:
+		if o.NewEstimate == "" {
+			return fmt.Errorf("adjustEstimate %q requires a new estimate", o.AdjustEstimate)
+		}
+		return nil
+	case AdjustEstimateManual:
+		switch {
+		case method == "POST" && o.ReduceBy == "":
+			return fmt.Errorf("adjustEstimate %q requires reduceBy", o.AdjustEstimate)
+		case method == "DELETE" && o.IncreaseBy == "":
+			return fmt.Errorf("adjustEstimate %q requires increaseBy", o.AdjustEstimate)
+		case method == "PUT":
+			return fmt.Errorf("adjustEstimate %q is not supported when a worklog is updated", o.AdjustEstimate)
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown adjustEstimate %q", o.AdjustEstimate)
+}
+
+// GetWorklogsWithContext returns one page of the worklogs of the issue issueID.
+// The paging information of the page is part of the returned Worklog and Response. options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-getIssueWorklog
+func (s *IssueService) GetWorklogsWithContext(ctx context.Context, issueID string, options *GetWorklogsOptions) (*Worklog, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog", issueID)
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	worklog := new(Worklog)
+	resp, err := s.client.Do(req, worklog)
+	if err != nil {
+		return nil, resp, err
+	}
+	return worklog, resp, nil
+}
+
+// GetWorklogs wraps GetWorklogsWithContext using the background context.
+func (s *IssueService) GetWorklogs(issueID string, options *GetWorklogsOptions) (*Worklog, *Response, error) {
+	return s.GetWorklogsWithContext(context.Background(), issueID, options)
+}
+
+// GetAllWorklogsWithContext returns all worklogs of the issue issueID.
+// All pages are fetched one after the other.
+func (s *IssueService) GetAllWorklogsWithContext(ctx context.Context, issueID string) ([]WorklogRecord, *Response, error) {
+	var records []WorklogRecord
+	options := &GetWorklogsOptions{}
+	for {
+		worklog, resp, err := s.GetWorklogsWithContext(ctx, issueID, options)
+		if err != nil {
+			return nil, resp, err
+		}
+		records = append(records, worklog.Worklogs...)
+		options.StartAt += len(worklog.Worklogs)
+		if len(worklog.Worklogs) == 0 || options.StartAt >= worklog.Total {
+			return records, resp, nil
+		}
+	}
+}
+
+// GetAllWorklogs wraps GetAllWorklogsWithContext using the background context.
+func (s *IssueService) GetAllWorklogs(issueID string) ([]WorklogRecord, *Response, error) {
+	return s.GetAllWorklogsWithContext(context.Background(), issueID)
+}
+
+// AddWorklogRecordWithContext logs work on the issue issueID.
+// Comment, Started, TimeSpent (or TimeSpentSeconds) and Visibility of record are used. options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-addWorklog
+func (s *IssueService) AddWorklogRecordWithContext(ctx context.Context, issueID string, record *WorklogRecord, options *WorklogOptions) (*WorklogRecord, *Response, error) {
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog", issueID)
+	return s.sendWorklogRecord(ctx, "POST", apiEndpoint, record, options)
+}
+
+// AddWorklogRecord wraps AddWorklogRecordWithContext using the background context.
+func (s *IssueService) AddWorklogRecord(issueID string, record *WorklogRecord, options *WorklogOptions) (*WorklogRecord, *Response, error) {
+	return s.AddWorklogRecordWithContext(context.Background(), issueID, record, options)
+}
+
+// UpdateWorklogRecordWithContext updates the worklog record.ID of the issue issueID.
+// Comment, Started, TimeSpent (or TimeSpentSeconds) and Visibility of record are used. options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-updateWorklog
+func (s *IssueService) UpdateWorklogRecordWithContext(ctx context.Context, issueID string, record *WorklogRecord, options *WorklogOptions) (*WorklogRecord, *Response, error) {
+	if record.ID == "" {
+		return nil, nil, fmt.Errorf("the worklog to update has no id")
+	}
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog/%s", issueID, record.ID)
+	return s.sendWorklogRecord(ctx, "PUT", apiEndpoint, record, options)
+}
+
+// UpdateWorklogRecord wraps UpdateWorklogRecordWithContext using the background context.
+func (s *IssueService) UpdateWorklogRecord(issueID string, record *WorklogRecord, options *WorklogOptions) (*WorklogRecord, *Response, error) {
+	return s.UpdateWorklogRecordWithContext(context.Background(), issueID, record, options)
+}
+
+// sendWorklogRecord adds or updates a worklog and returns the worklog as stored by JIRA.
+func (s *IssueService) sendWorklogRecord(ctx context.Context, method, apiEndpoint string, record *WorklogRecord, options *WorklogOptions) (*WorklogRecord, *Response, error) {
+	if err := options.validate(method); err != nil {
+		return nil, nil, err
+	}
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, method, apiEndpoint, newWorklogRequest(record))
+	if err != nil {
+		return nil, nil, err
+	}
+
+	responseRecord := new(WorklogRecord)
+	resp, err := s.client.Do(req, responseRecord)
+	if err != nil {
+		return nil, resp, err
+	}
+	return responseRecord, resp, nil
+}
+
+// DeleteWorklogRecordWithContext deletes the worklog worklogID of the issue issueID. options can be nil.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/issue-deleteWorklog
+func (s *IssueService) DeleteWorklogRecordWithContext(ctx context.Context, issueID, worklogID string, options *WorklogOptions) (*Response, error) {
+	if err := options.validate("DELETE"); err != nil {
+		return nil, err
+	}
+	apiEndpoint := fmt.Sprintf("rest/api/2/issue/%s/worklog/%s", issueID, worklogID)
+	apiEndpoint, err := addOptions(apiEndpoint, options)
+	if err != nil {
+		return nil, err
+	}
+	req, err := s.client.NewRequestWithContext(ctx, "DELETE", apiEndpoint, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := s.client.Do(req, nil)
+	return resp, err
+}
+
+// DeleteWorklogRecord wraps DeleteWorklogRecordWithContext using the background context.
+func (s *IssueService) DeleteWorklogRecord(issueID, worklogID string, options *WorklogOptions) (*Response, error) {
+	return s.DeleteWorklogRecordWithContext(context.Background(), issueID, worklogID, options)
+}
+
+// GetUpdatedWorklogsWithContext returns one page of the ids of the worklogs created or updated since the time since.
+// If the page is not the last one, the next page is requested with the Until of the page as since.
+// Worklogs updated during the last minute may not be returned yet.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/worklog-getIdsOfWorklogsModifiedSince
+func (s *IssueService) GetUpdatedWorklogsWithContext(ctx context.Context, since time.Time) (*WorklogChanges, *Response, error) {
+	return s.getWorklogChanges(ctx, "rest/api/2/worklog/updated", since)
+}
+
+// GetUpdatedWorklogs wraps GetUpdatedWorklogsWithContext using the background context.
+func (s *IssueService) GetUpdatedWorklogs(since time.Time) (*WorklogChanges, *Response, error) {
+	return s.GetUpdatedWorklogsWithContext(context.Background(), since)
+}
+
+// GetDeletedWorklogsWithContext returns one page of the ids of the worklogs deleted since the time since.
+// The pages are requested like the ones of GetUpdatedWorklogs.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/worklog-getIdsOfWorklogsDeletedSince
+func (s *IssueService) GetDeletedWorklogsWithContext(ctx context.Context, since time.Time) (*WorklogChanges, *Response, error) {
+	return s.getWorklogChanges(ctx, "rest/api/2/worklog/deleted", since)
+}
+
+// GetDeletedWorklogs wraps GetDeletedWorklogsWithContext using the background context.
+func (s *IssueService) GetDeletedWorklogs(since time.Time) (*WorklogChanges, *Response, error) {
+	return s.GetDeletedWorklogsWithContext(context.Background(), since)
+}
+
+// getWorklogChanges requests one page of the worklog changes at apiEndpoint.
+func (s *IssueService) getWorklogChanges(ctx context.Context, apiEndpoint string, since time.Time) (*WorklogChanges, *Response, error) {
+	apiEndpoint = fmt.Sprintf("%s?since=%d", apiEndpoint, toMillis(since))
+	req, err := s.client.NewRequestWithContext(ctx, "GET", apiEndpoint, nil)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	changes := new(WorklogChanges)
+	resp, err := s.client.Do(req, changes)
+	if err != nil {
+		return nil, resp, err
+	}
+	return changes, resp, nil
+}
+
+// GetWorklogsByIDWithContext returns the worklogs with the ids ids, e.g. as returned by GetUpdatedWorklogs.
+// Worklogs the user is not allowed to see are not returned.
+// The ids are requested in batches of 1000, the maximum JIRA accepts.
+//
+// JIRA API docs: https://docs.atlassian.com/jira/REST/latest/#api/2/worklog-getWorklogsForIds
+func (s *IssueService) GetWorklogsByIDWithContext(ctx context.Context, ids []int) ([]WorklogRecord, *Response, error) {
+	apiEndpoint := "rest/api/2/worklog/list"
+	var records []WorklogRecord
+	var resp *Response
+	for start := 0; start < len(ids); start += worklogListLimit {
+		end := start + worklogListLimit
+		if end > len(ids) {
+			end = len(ids)
+		}
+		body := struct {
+			IDs []int `json:"ids"`
+		}{ids[start:end]}
+		req, err := s.client.NewRequestWithContext(ctx, "POST", apiEndpoint, body)
+		if err != nil {
+			return nil, resp, err
+		}
+
+		var batch []WorklogRecord
+		resp, err = s.client.Do(req, &batch)
+		if err != nil {
+			return nil, resp, err
+		}
+		records = append(records, batch...)
+	}
+	return records, resp, nil
+}
+
+// GetWorklogsByID wraps GetWorklogsByIDWithContext using the background context.
+func (s *IssueService) GetWorklogsByID(ids []int) ([]WorklogRecord, *Response, error) {
+	return s.GetWorklogsByIDWithContext(context.Background(), ids)
+}
+
+// SyncWorklogsWithContext returns all worklogs created, updated or deleted since the checkpoint since.
+// Store the returned Until and pass it as since to the next call to get the following changes.
+// A worklog may be returned by two successive calls, so the changes should be applied idempotently.
+func (s *IssueService) SyncWorklogsWithContext(ctx context.Context, since time.Time) (*WorklogSync, *Response, error) {
+	updated, updatedUntil, resp, err := s.allWorklogChanges(ctx, s.GetUpdatedWorklogsWithContext, since)
+	if err != nil {
+		return nil, resp, err
+	}
+	deleted, deletedUntil, resp, err := s.allWorklogChanges(ctx, s.GetDeletedWorklogsWithContext, since)
+	if err != nil {
+		return nil, resp, err
+	}
+
+	sync := &WorklogSync{Deleted: deleted, Until: updatedUntil}
+	if deletedUntil.Before(sync.Until) {
+		sync.Until = deletedUntil
+	}
+	if len(updated) > 0 {
+		sync.Updated, resp, err = s.GetWorklogsByIDWithContext(ctx, updated)
+		if err != nil {
+			return nil, resp, err
+		}
+	}
+	return sync, resp, nil
+}
+
+// SyncWorklogs wraps SyncWorklogsWithContext using the background context.
+func (s *IssueService) SyncWorklogs(since time.Time) (*WorklogSync, *Response, error) {
+	return s.SyncWorklogsWithContext(context.Background(), since)
+}
+
+// allWorklogChanges fetches all pages of get starting at since.
+// It returns the ids of the changed worklogs and the end of the last page.
+func (s *IssueService) allWorklogChanges(ctx context.Context, get func(context.Context, time.Time) (*WorklogChanges, *Response, error), since time.Time) ([]int, time.Time, *Response, error) {
+	var ids []int
+	for {
+		changes, resp, err := get(ctx, since)
+		if err != nil {
+			return nil, since, resp, err
+		}
+		for _, change := range changes.Values {
+			ids = append(ids, change.WorklogID)
+		}
+		until := fromMillis(changes.Until)
+		// Protection against endless loops if the reported paging information is inconsistent
+		if changes.LastPage || !until.After(since) {
+			if until.After(since) {
+				since = until
+			}
+			return ids, since, resp, nil
+		}
+		since = until
+	}
+}
+
+// toMillis returns t in milliseconds since the epoch.
+func toMillis(t time.Time) int64 {
+	return t.UnixNano() / int64(time.Millisecond)
+}
+
+// fromMillis returns the time of ms milliseconds since the epoch.
+func fromMillis(ms int64) time.Time {
+	return time.Unix(0, ms*int64(time.Millisecond))
+}
@@ -0,0 +1,241 @@
+package jira
+
+import (
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+const testWorklogRecord = `{
+	"self": "http://www.example.com/jira/rest/api/2/issue/10010/worklog/10000",
+	"author": {"name": "fred"},
+	"comment": "I did some work here.",
+	"created": "2016-03-16T04:22:37.356-0700",
+	"updated": "2016-03-16T04:22:37.356-0700",
+	"started": "2016-03-16T04:22:37.356-0700",
+	"timeSpent": "3h 20m",
+	"timeSpentSeconds": 12000,
+	"id": "10000",
+	"issueId": "10010"
+}`
+
+func TestIssueService_GetWorklogs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10010/worklog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/issue/10010/worklog?maxResults=1&startAt=1")
+		fmt.Fprintf(w, `{"startAt": 1, "maxResults": 1, "total": 2, "worklogs": [%s]}`, testWorklogRecord)
+	})
+
+	worklog, resp, err := testClient.Issue.GetWorklogs("10010", &GetWorklogsOptions{StartAt: 1, MaxResults: 1})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(worklog.Worklogs) != 1 || worklog.Worklogs[0].TimeSpentSeconds != 12000 || worklog.Worklogs[0].Author.Name != "fred" {
+		t.Errorf("Unexpected worklogs %+v", worklog.Worklogs)
+	}
+	if resp.StartAt != 1 || resp.MaxResults != 1 || resp.Total != 2 {
+		t.Errorf("Unexpected paging information %d, %d, %d", resp.StartAt, resp.MaxResults, resp.Total)
+	}
+}
+
+func TestIssueService_GetAllWorklogs(t *testing.T) {
+	setup()
+	defer teardown()
+	calls := 0
+	testMux.HandleFunc("/rest/api/2/issue/10010/worklog", func(w http.ResponseWriter, r *http.Request) {
+		calls++
+		startAt := r.URL.Query().Get("startAt")
+		if (calls == 1 && startAt != "") || (calls == 2 && startAt != "1") {
+			t.Errorf("Unexpected startAt %q in call %d", startAt, calls)
+		}
+		fmt.Fprintf(w, `{"startAt": %d, "maxResults": 1, "total": 2, "worklogs": [%s]}`, calls-1, testWorklogRecord)
+	})
+
+	records, _, err := testClient.Issue.GetAllWorklogs("10010")
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(records) != 2 || calls != 2 {
+		t.Errorf("Expected 2 worklogs in 2 calls, recieved %d in %d calls", len(records), calls)
+	}
+}
+
+func TestIssueService_AddWorklogRecord(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10010/worklog", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		testRequestURL(t, r, "/rest/api/2/issue/10010/worklog?adjustEstimate=manual&reduceBy=2h")
+		b, _ := ioutil.ReadAll(r.Body)
+		want := `{"comment":"I did some work here.","started":"2016-03-16T04:22:37.000-0700","timeSpent":"3h 20m","visibility":{"type":"group","value":"jira-developers"}}`
+		if got := string(b); got != want+"\n" {
+			t.Errorf("Expected body %s, recieved %s", want, got)
+		}
+		w.WriteHeader(http.StatusCreated)
+		fmt.Fprint(w, testWorklogRecord)
+	})
+
+	started := Time(time.Date(2016, 3, 16, 4, 22, 37, 0, time.FixedZone("", -7*60*60)))
+	record := &WorklogRecord{
+		ID:         "ignored",
+		Author:     User{Name: "ignored"},
+		Comment:    "I did some work here.",
+		Started:    &started,
+		TimeSpent:  "3h 20m",
+		Visibility: &CommentVisibility{Type: "group", Value: "jira-developers"},
+	}
+	created, _, err := testClient.Issue.AddWorklogRecord("10010", record, &WorklogOptions{AdjustEstimate: AdjustEstimateManual, ReduceBy: "2h"})
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if created.ID != "10000" {
+		t.Errorf("Expected id 10000, recieved %s", created.ID)
+	}
+}
+
+func TestIssueService_UpdateWorklogRecord(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10010/worklog/10000", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "PUT")
+		testRequestURL(t, r, "/rest/api/2/issue/10010/worklog/10000?adjustEstimate=new&newEstimate=1d")
+		b, _ := ioutil.ReadAll(r.Body)
+		if want := `{"timeSpentSeconds":3600}`; string(b) != want+"\n" {
+			t.Errorf("Expected body %s, recieved %s", want, b)
+		}
+		fmt.Fprint(w, testWorklogRecord)
+	})
+
+	record := &WorklogRecord{ID: "10000", TimeSpentSeconds: 3600}
+	if _, _, err := testClient.Issue.UpdateWorklogRecord("10010", record, &WorklogOptions{AdjustEstimate: AdjustEstimateNew, NewEstimate: "1d"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+
+	if _, _, err := testClient.Issue.UpdateWorklogRecord("10010", &WorklogRecord{}, nil); err == nil {
+		t.Error("Expected an error for a worklog without id")
+	}
+}
+
+func TestIssueService_DeleteWorklogRecord(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/issue/10010/worklog/10000", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "DELETE")
+		testRequestURL(t, r, "/rest/api/2/issue/10010/worklog/10000?adjustEstimate=manual&increaseBy=3h")
+		w.WriteHeader(http.StatusNoContent)
+	})
+
+	if _, err := testClient.Issue.DeleteWorklogRecord("10010", "10000", &WorklogOptions{AdjustEstimate: AdjustEstimateManual, IncreaseBy: "3h"}); err != nil {
+		t.Errorf("Error given: %s", err)
+	}
+}
+
+func TestWorklogOptions_Validate(t *testing.T) {
+	for _, tc := range []struct {
+		method  string
+		options *WorklogOptions
+		valid   bool
+	}{
+		{"POST", nil, true},
+		{"POST", &WorklogOptions{AdjustEstimate: AdjustEstimateLeave}, true},
+		{"POST", &WorklogOptions{AdjustEstimate: AdjustEstimateNew}, false},
+		{"POST", &WorklogOptions{AdjustEstimate: AdjustEstimateManual}, false},
+		{"POST", &WorklogOptions{AdjustEstimate: AdjustEstimateManual, ReduceBy: "1h"}, true},
+		{"PUT", &WorklogOptions{AdjustEstimate: AdjustEstimateManual, ReduceBy: "1h"}, false},
+		{"DELETE", &WorklogOptions{AdjustEstimate: AdjustEstimateManual, ReduceBy: "1h"}, false},
+		{"DELETE", &WorklogOptions{AdjustEstimate: "sometimes"}, false},
+	} {
+		if err := tc.options.validate(tc.method); (err == nil) != tc.valid {
+			t.Errorf("%s %+v: Expected valid %t, recieved %v", tc.method, tc.options, tc.valid, err)
+		}
+	}
+}
+
+func TestIssueService_GetUpdatedWorklogs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/worklog/updated", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "GET")
+		testRequestURL(t, r, "/rest/api/2/worklog/updated?since=1438013671562")
+		fmt.Fprint(w, `{"values": [{"worklogId": 103, "updatedTime": 1438013671562}], "since": 1438013671562, "until": 1438013693136, "self": "", "lastPage": true}`)
+	})
+
+	changes, _, err := testClient.Issue.GetUpdatedWorklogs(fromMillis(1438013671562))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(changes.Values) != 1 || changes.Values[0].WorklogID != 103 || changes.Until != 1438013693136 || !changes.LastPage {
+		t.Errorf("Unexpected changes %+v", changes)
+	}
+}
+
+func TestIssueService_GetWorklogsByID(t *testing.T) {
+	setup()
+	defer teardown()
+	var batches []int
+	testMux.HandleFunc("/rest/api/2/worklog/list", func(w http.ResponseWriter, r *http.Request) {
+		testMethod(t, r, "POST")
+		var body struct {
+			IDs []int `json:"ids"`
+		}
+		if err := json.NewDecoder(r.Body).Decode(&body); err != nil {
+			t.Fatalf("Error given: %s", err)
+		}
+		batches = append(batches, len(body.IDs))
+		fmt.Fprintf(w, `[%s]`, testWorklogRecord)
+	})
+
+	ids := make([]int, 1500)
+	records, _, err := testClient.Issue.GetWorklogsByID(ids)
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(batches) != 2 || batches[0] != 1000 || batches[1] != 500 {
+		t.Errorf("Expected batches of 1000 and 500 ids, recieved %v", batches)
+	}
+	if len(records) != 2 {
+		t.Errorf("Expected 2 worklogs, recieved %d", len(records))
+	}
+}
+
+func TestIssueService_SyncWorklogs(t *testing.T) {
+	setup()
+	defer teardown()
+	testMux.HandleFunc("/rest/api/2/worklog/updated", func(w http.ResponseWriter, r *http.Request) {
+		switch since := r.URL.Query().Get("since"); since {
+		case "1000":
+			fmt.Fprint(w, `{"values": [{"worklogId": 1}, {"worklogId": 2}], "since": 1000, "until": 2000, "lastPage": false}`)
+		case "2000":
+			fmt.Fprint(w, `{"values": [{"worklogId": 3}], "since": 2000, "until": 3000, "lastPage": true}`)
+		default:
+			t.Errorf("Unexpected since %s", since)
+		}
+	})
+	testMux.HandleFunc("/rest/api/2/worklog/deleted", func(w http.ResponseWriter, r *http.Request) {
+		testRequestURL(t, r, "/rest/api/2/worklog/deleted?since=1000")
+		fmt.Fprint(w, `{"values": [{"worklogId": 4}], "since": 1000, "until": 2500, "lastPage": true}`)
+	})
+	testMux.HandleFunc("/rest/api/2/worklog/list", func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		if want := `{"ids":[1,2,3]}`; string(b) != want+"\n" {
+			t.Errorf("Expected body %s, recieved %s", want, b)
+		}
+		fmt.Fprint(w, `[{"id": "1"}, {"id": "2"}, {"id": "3"}]`)
+	})
+
+	sync, _, err := testClient.Issue.SyncWorklogs(fromMillis(1000))
+	if err != nil {
+		t.Fatalf("Error given: %s", err)
+	}
+	if len(sync.Updated) != 3 || len(sync.Deleted) != 1 || sync.Deleted[0] != 4 {
+		t.Errorf("Unexpected sync %+v", sync)
+	}
+	if want := fromMillis(2500); !sync.Until.Equal(want) {
+		t.Errorf("Expected the checkpoint %s, recieved %s", want, sync.Until)
+	}
+}